@@ -372,10 +372,12 @@ func (b *bsi) bit(x, y uint64) bool { return b.ms[x].Contains(y) }
 
 func (b *bsi) subMap(x uint64) *roaring.Bitmap { return b.ms[x] }
 
+// show writes a copy of mp, since the containers emptied by removal are
+// counted but not written by roaring, which cannot be read back.
 func show(mp *roaring.Bitmap) ([]byte, error) {
 	var buf bytes.Buffer
 
-	if _, err := mp.WriteTo(&buf); err != nil {
+	if _, err := roaring.NewBitmap().Union(mp).WriteTo(&buf); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -125,3 +125,29 @@ func TestUbsi(t *testing.T) {
 		}
 	}
 }
+
+func TestShowOverwritten(t *testing.T) {
+	mp := New(64)
+	for i, k := range []uint64{1, 2, 2, 5} {
+		if err := mp.Set(k, uint64(i+1)*100); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mp.Del(5); err != nil {
+		t.Fatal(err)
+	}
+	data, err := mp.Show()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mq := New(0)
+	if err := mq.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := mq.Get(2); !ok || v != uint64(300) {
+		t.Errorf("Get(2) = %v, %v, want 300", v, ok)
+	}
+	if _, ok := mq.Get(5); ok {
+		t.Errorf("Get(5) exists, want deleted")
+	}
+}
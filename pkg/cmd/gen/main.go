@@ -61,6 +61,7 @@ func main() {
 		if err != nil {
 			continue
 		}
+		// xid = uid<<34 | pid, the default layout of tables
 		fmt.Printf("%v,%v,%v,%v,%v,%v,%v,\"%s\"\n",
 			uid, (pid | uid<<34), t[0], String(17), sexs[uid%2], cities[int(uid)%len(cities)], time.Now().Add(time.Duration(pid|uid<<34)).Format(TimestampOutputFormat), vec)
 		uid++
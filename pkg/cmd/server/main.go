@@ -13,12 +13,16 @@ import (
 	"github.com/deepfabric/vectorsql/pkg/lru"
 	"github.com/deepfabric/vectorsql/pkg/routines"
 	"github.com/deepfabric/vectorsql/pkg/server"
+	"github.com/deepfabric/vectorsql/pkg/sql/build"
 	"github.com/deepfabric/vectorsql/pkg/sql/client"
 	"github.com/deepfabric/vectorsql/pkg/storage"
 	"github.com/deepfabric/vectorsql/pkg/storage/cache"
+	"github.com/deepfabric/vectorsql/pkg/storage/vectors"
 	"github.com/deepfabric/vectorsql/pkg/vector"
 	"github.com/deepfabric/vectorsql/pkg/vm/bv"
 	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
 )
 
 var (
@@ -48,6 +52,12 @@ func main() {
 	case "panic":
 		log.SetLevel(logger.PANIC)
 	}
+	if len(cfg.Timezone) == 0 {
+		cfg.Timezone = config.DefaultTimezone
+	}
+	if err := value.SetLocation(cfg.Timezone); err != nil {
+		log.Fatal(err)
+	}
 	db := pb.New(cfg.Db, nil, 0, false, false)
 	defer db.Close()
 	cli, err := client.New(cfg.Dsn)
@@ -56,11 +66,17 @@ func main() {
 	}
 	defer cli.Close()
 	vec := vector.New(cfg.Url)
-	b := bv.New(cfg.Addrs, log)
+	b := bv.New(cfg.Addrs, vectors.New(build.VectorDim, db), log)
 	stg := storage.New(db, lru.New(100), cache.New(cfg.CacheSize))
 	defer stg.Close()
 	scfg := &server.Config{
-		B:   b,
+		B: b,
+		Cfg: &op.Config{
+			Dim:        build.VectorDim,
+			ExactLimit: cfg.ExactLimit,
+			RangeLimit: cfg.RangeLimit,
+			FtopLimit:  cfg.FtopLimit,
+		},
 		Log: log,
 		Cli: cli,
 		Vec: vec,
@@ -1,14 +1,22 @@
 package config
 
+// DefaultTimezone is the time zone of datetimes if timezone is not given,
+// it is UTC+8 which the old versions use.
+const DefaultTimezone = "Asia/Shanghai"
+
 type Config struct {
-	Port      int      `toml:"port"`
-	Routines  int      `toml:"routines"`
-	Db        string   `toml:"db"` // database name
-	Dsn       string   `toml:"dsn"`
-	Url       string   `toml:"url"`
-	CacheSize int      `toml:"cachesize"`
-	Addrs     []string `toml:"addrs"`
-	LogConfig *Log     `toml:"log"`
+	Port       int      `toml:"port"`
+	Routines   int      `toml:"routines"`
+	Db         string   `toml:"db"` // database name
+	Dsn        string   `toml:"dsn"`
+	Url        string   `toml:"url"`
+	CacheSize  int      `toml:"cachesize"`
+	ExactLimit int      `toml:"exactlimit"` // maximum number of candidates for exact search
+	RangeLimit int      `toml:"rangelimit"` // maximum number of results for range search
+	FtopLimit  int      `toml:"ftoplimit"`  // maximum number of candidates for ftop
+	Timezone   string   `toml:"timezone"`   // time zone of datetimes, such as Asia/Shanghai, default is DefaultTimezone
+	Addrs      []string `toml:"addrs"`
+	LogConfig  *Log     `toml:"log"`
 }
 
 type Log struct {
@@ -8,31 +8,42 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"reflect"
+	"sort"
 	"strconv"
-	"strings"
 	"time"
 
+	"github.com/deepfabric/vectorsql/pkg/lru"
 	"github.com/deepfabric/vectorsql/pkg/request"
 	"github.com/deepfabric/vectorsql/pkg/routines/task"
 	"github.com/deepfabric/vectorsql/pkg/sql/build"
 	"github.com/deepfabric/vectorsql/pkg/sql/client"
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
+	"github.com/deepfabric/vectorsql/pkg/storage"
 	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vector"
+	"github.com/deepfabric/vectorsql/pkg/vm/bv"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
 	"github.com/deepfabric/vectorsql/pkg/vm/types"
 	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/pilosa/pilosa/roaring"
 	"github.com/valyala/fasthttp"
 )
 
 func New(port int, dsn string, cfg *Config) Server {
 	return &server{
-		dsn:  dsn,
-		port: port,
-		b:    cfg.B,
-		cli:  cfg.Cli,
-		log:  cfg.Log,
-		stg:  cfg.Stg,
-		vec:  cfg.Vec,
-		ctx:  cfg.Ctx,
-		rts:  cfg.Rts,
+		dsn:   dsn,
+		port:  port,
+		b:     cfg.B,
+		cfg:   cfg.Cfg,
+		cli:   cfg.Cli,
+		log:   cfg.Log,
+		stg:   cfg.Stg,
+		vec:   cfg.Vec,
+		ctx:   cfg.Ctx,
+		rts:   cfg.Rts,
+		stmts: lru.New(MaxStmts),
 	}
 }
 
@@ -48,10 +59,16 @@ func (s *server) Run() {
 				s.dealQueryWithVector(ctx)
 			case "/create":
 				s.dealCreate(ctx)
+			case "/exec":
+				s.dealExec(ctx)
 			case "/insert":
-				s.dealInsert(ctx)
+				s.dealInsert(ctx, false)
 			case "/insertWithVector":
-				s.dealInsertWithVector(ctx)
+				s.dealInsertWithVector(ctx, false)
+			case "/update":
+				s.dealInsert(ctx, true)
+			case "/updateWithVector":
+				s.dealInsertWithVector(ctx, true)
 			default:
 				ctx.Error("Unsupport Path", fasthttp.StatusNotFound)
 			}
@@ -73,13 +90,13 @@ func (s *server) dealQuery(ctx *fasthttp.RequestCtx) {
 	ctx.Response.SetStatusCode(200)
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
 	ctx.Response.Header.Set("Content-Type", "application/json")
-	qr, vec, err := s.extractParameters(ctx)
+	qr, args, vec, vs, err := s.extractParameters(ctx)
 	if err != nil {
 		ctx.Response.SetStatusCode(400)
 		ctx.Write([]byte(err.Error()))
 		return
 	}
-	o, err := build.New(qr, s.ctx, s.stg).Build()
+	o, err := s.build(qr, args)
 	if err != nil {
 		ctx.Response.SetStatusCode(400)
 		ctx.Write([]byte(err.Error()))
@@ -97,12 +114,16 @@ func (s *server) dealQuery(ctx *fasthttp.RequestCtx) {
 	{
 		s.log.Debugf("IF: %v\n", o.If)
 	}
-	rows, err := o.Result(s.log, s.b, s.cli, vec)
+	o.Vs = vs
+	rows, err := o.Result(s.log, s.cfg, s.b, s.cli, vec)
 	if err != nil {
 		ctx.Response.SetStatusCode(400)
 		ctx.Write([]byte(err.Error()))
 		return
 	}
+	if o.T != nil && o.T.IsF {
+		ctx.Response.Header.Set("X-Search-Rounds", strconv.Itoa(o.T.Rounds))
+	}
 	if err := csv.NewWriter(bufio.NewWriter(&buf)).WriteAll(rows); err != nil {
 		ctx.Response.SetStatusCode(500)
 		ctx.Write([]byte(err.Error()))
@@ -117,13 +138,13 @@ func (s *server) dealQueryWithVector(ctx *fasthttp.RequestCtx) {
 	ctx.Response.SetStatusCode(200)
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
 	ctx.Response.Header.Set("Content-Type", "application/json")
-	qr, vec, err := s.extractParametersWithVector(ctx)
+	qr, args, vec, vs, err := s.extractParametersWithVector(ctx)
 	if err != nil {
 		ctx.Response.SetStatusCode(400)
 		ctx.Write([]byte(err.Error()))
 		return
 	}
-	o, err := build.New(qr, s.ctx, s.stg).Build()
+	o, err := s.build(qr, args)
 	if err != nil {
 		ctx.Response.SetStatusCode(400)
 		ctx.Write([]byte(err.Error()))
@@ -141,12 +162,16 @@ func (s *server) dealQueryWithVector(ctx *fasthttp.RequestCtx) {
 	{
 		s.log.Debugf("IF: %v\n", o.If)
 	}
-	rows, err := o.Result(s.log, s.b, s.cli, vec)
+	o.Vs = vs
+	rows, err := o.Result(s.log, s.cfg, s.b, s.cli, vec)
 	if err != nil {
 		ctx.Response.SetStatusCode(400)
 		ctx.Write([]byte(err.Error()))
 		return
 	}
+	if o.T != nil && o.T.IsF {
+		ctx.Response.Header.Set("X-Search-Rounds", strconv.Itoa(o.T.Rounds))
+	}
 	if err := csv.NewWriter(bufio.NewWriter(&buf)).WriteAll(rows); err != nil {
 		ctx.Response.SetStatusCode(500)
 		ctx.Write([]byte(err.Error()))
@@ -169,56 +194,29 @@ func (s *server) dealCreate(ctx *fasthttp.RequestCtx) {
 	if n := len(req.Item); n > 0 {
 		var md metadata.Metadata
 
-		if n < 3 {
-			ctx.Response.SetStatusCode(400)
-			ctx.Write([]byte(fmt.Sprintf("need at least uid, xid, pic attributes")))
-			return
-		}
-		md.IsE = false
-		id := metadata.Ikey(req.Name)
-		sql := fmt.Sprintf("CREATE TABLE %s ", id)
+		md.Layout = metadata.Layout{UidBits: req.UidBits, PidBits: req.PidBits}
 		md.Attrs = make([]metadata.Attribute, n)
 		for i := 0; i < n; i++ {
 			md.Attrs[i].Name = req.Item[i].Name
 			md.Attrs[i].Index = req.Item[i].Index
-			name, typ := stringToType(req.Item[i].Type)
+			tname, nullable := build.NullableType(req.Item[i].Type)
+			name, typ, dim := build.AttributeType(tname)
 			if len(name) == 0 {
 				ctx.Response.SetStatusCode(400)
-				ctx.Write([]byte(fmt.Sprintf("unsupport type '%s'", req.Item[i].Type)))
+				ctx.Write([]byte(build.TypeError(req.Item[i].Type).Error()))
 				return
 			}
+			md.Attrs[i].Dim = dim
 			md.Attrs[i].Type = typ
-			if i == 0 {
-				sql += fmt.Sprintf("(%s %s", req.Item[i].Name, name)
-			} else {
-				sql += fmt.Sprintf(", %s %s", req.Item[i].Name, name)
-			}
-		}
-		if md.Attrs[0].Name != "uid" && md.Attrs[0].Type != types.T_uint64 {
-			ctx.Response.SetStatusCode(400)
-			ctx.Write([]byte("need attribute uid(uint64)"))
-			return
+			md.Attrs[i].Nullable = nullable || req.Item[i].Nullable
 		}
-		if md.Attrs[1].Name != "xid" && md.Attrs[1].Type != types.T_uint64 {
-			ctx.Response.SetStatusCode(400)
-			ctx.Write([]byte("need attribute xid(uint64)"))
-			return
-		}
-		if md.Attrs[2].Name != "pic" && md.Attrs[2].Type != types.T_string {
+		c, err := build.NewCreate(req.Name, md, build.DefaultTableOptions)
+		if err != nil {
 			ctx.Response.SetStatusCode(400)
-			ctx.Write([]byte("need attribute pic(string)"))
-			return
-		}
-		sql += fmt.Sprintf(") engine=ReplacingMergeTree() PARTITION BY intDiv(uid, 1000000) ORDER BY (uid);")
-		if err := s.stg.NewRelation(id, md); err != nil {
-			ctx.Response.SetStatusCode(500)
 			ctx.Write([]byte(err.Error()))
 			return
 		}
-		{
-			s.log.Debugf("create table use '%s'\n", sql)
-		}
-		if err := s.cli.Exec(sql, nil); err != nil {
+		if err := s.create(c); err != nil {
 			ctx.Response.SetStatusCode(500)
 			ctx.Write([]byte(err.Error()))
 			return
@@ -227,9 +225,9 @@ func (s *server) dealCreate(ctx *fasthttp.RequestCtx) {
 	ctx.Write([]byte("success"))
 }
 
-func (s *server) dealInsert(ctx *fasthttp.RequestCtx) {
-	var rids []string // removed remove id list
-
+// dealInsert inserts the tuples, the old vectors of the uids are replaced
+// if isU is true.
+func (s *server) dealInsert(ctx *fasthttp.RequestCtx, isU bool) {
 	ctx.Response.SetStatusCode(200)
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
 	ctx.Response.Header.Set("Content-Type", "application/json")
@@ -252,83 +250,16 @@ func (s *server) dealInsert(ctx *fasthttp.RequestCtx) {
 		ctx.Write([]byte(err.Error()))
 		return
 	}
-	attrs := r.Metadata().Attrs
-	for len(ts) > 0 {
-		{
-			s.log.Debugf("tuples %v\n", len(ts))
-		}
-		n := len(ts)
-		if n > 5000 {
-			n = 5000
-		}
-		rs, xbs, xids, iargs, cargs, err := s.convert(ts[:n], attrs)
-		if err != nil {
-			ctx.Response.SetStatusCode(400)
-			ctx.Write([]byte(err.Error()))
-			return
-		}
-		rids = append(rids, rs...)
-		{
-			query := fmt.Sprintf("insert into %s (", id)
-			for i, attr := range attrs {
-				if i == 0 {
-					query += fmt.Sprintf("%s", attr.Name)
-				} else {
-					query += fmt.Sprintf(", %s", attr.Name)
-				}
-			}
-			query += ") VALUES"
-			{
-				s.log.Debugf("convert ok %v: %v -> %v\n", query, n, len(cargs))
-			}
-			for _, _ = range cargs {
-				query += " ("
-				for i := range attrs {
-					if i == 0 {
-						query += "?"
-					} else {
-						query += ", ?"
-					}
-				}
-				query += ")"
-			}
-			cli, err := client.New(s.dsn)
-			if err != nil {
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
-			}
-			if err := cli.Exec(query, cargs); err != nil {
-				cli.Close()
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
-			}
-			cli.Close()
-		}
-		{
-			if err := r.AddTuples(iargs); err != nil {
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
-			}
-		}
-		{
-			{
-				s.log.Debugf("xbs: %v, xids: %v\n", len(xbs), len(xids))
-			}
-			if err := s.b.Add(xbs, xids); err != nil {
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
-			}
-		}
-		ts = ts[n:]
+	rids, code, err := s.insert(id, r, ts, isU, false)
+	if err != nil {
+		ctx.Response.SetStatusCode(code)
+		ctx.Write([]byte(err.Error()))
+		return
 	}
 	ctx.Write([]byte(fmt.Sprintf("success: skip uid list: %v", rids)))
 }
 
-func (s *server) dealInsertWithVector(ctx *fasthttp.RequestCtx) {
+func (s *server) dealInsertWithVector(ctx *fasthttp.RequestCtx, isU bool) {
 	ctx.Response.SetStatusCode(200)
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
 	ctx.Response.Header.Set("Content-Type", "application/json")
@@ -351,8 +282,92 @@ func (s *server) dealInsertWithVector(ctx *fasthttp.RequestCtx) {
 		ctx.Write([]byte(err.Error()))
 		return
 	}
-	attrs := r.Metadata().Attrs
+	if _, code, err := s.insert(id, r, ts, isU, true); err != nil {
+		ctx.Response.SetStatusCode(code)
+		ctx.Write([]byte(err.Error()))
+		return
+	}
+	ctx.Write([]byte(fmt.Sprintf("success")))
+}
+
+// dealExec executes the insert or create statement, such as
+// {"query": "insert into user (uid, xid, pic) values (1, 1, 'a.png')"}
+func (s *server) dealExec(ctx *fasthttp.RequestCtx) {
+	var mp map[string]interface{}
+
+	ctx.Response.SetStatusCode(200)
+	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
+	ctx.Response.Header.Set("Content-Type", "application/json")
+	if err := json.Unmarshal(ctx.PostBody(), &mp); err != nil {
+		ctx.Response.SetStatusCode(400)
+		ctx.Write([]byte(err.Error()))
+		return
+	}
+	qr, err := s.getSqlQuery(mp)
+	if err != nil {
+		ctx.Response.SetStatusCode(400)
+		ctx.Write([]byte(err.Error()))
+		return
+	}
+	n, c, err := build.New(qr, s.ctx, s.stg).BuildExec()
+	if err != nil {
+		ctx.Response.SetStatusCode(400)
+		ctx.Write([]byte(err.Error()))
+		return
+	}
+	if c != nil {
+		if err := s.create(c); err != nil {
+			ctx.Response.SetStatusCode(500)
+			ctx.Write([]byte(err.Error()))
+			return
+		}
+		ctx.Write([]byte("success"))
+		return
+	}
+	ts, err := n.Tuples(s.log, s.cfg, s.b, s.cli)
+	if err != nil {
+		ctx.Response.SetStatusCode(400)
+		ctx.Write([]byte(err.Error()))
+		return
+	}
+	rids, code, err := s.insert(n.Id, n.R, ts, false, false)
+	if err != nil {
+		ctx.Response.SetStatusCode(code)
+		ctx.Write([]byte(err.Error()))
+		return
+	}
+	ctx.Write([]byte(fmt.Sprintf("success: skip uid list: %v", rids)))
+}
+
+// create creates the relation in the index and clickhouse.
+func (s *server) create(c *op.Create) error {
+	if err := s.stg.NewRelation(c.Id, c.Md); err != nil {
+		return err
+	}
+	{
+		s.log.Debugf("create table use '%s'\n", c.Sql)
+	}
+	return s.cli.Exec(c.Sql, nil)
+}
+
+// insert inserts the tuples into clickhouse, the index and the vectors,
+// the vectors are extracted from the pictures unless isV is true, where
+// the vector is the last column of tuple. The uids whose vectors cannot
+// be extracted are skipped and returned with the status code.
+func (s *server) insert(id string, r storage.Relation, ts [][]string, isU, isV bool) ([]string, int, error) {
+	var rids []string // removed remove id list
+
+	md := r.Metadata()
+	attrs := md.Attrs
 	for len(ts) > 0 {
+		var err error
+		var rs []string
+		var xbs []float32
+		var xids []int64
+		var iargs []interface{}
+		var nargs []*roaring.Bitmap
+		var cargs [][]interface{}
+
 		{
 			s.log.Debugf("tuples %v\n", len(ts))
 		}
@@ -360,78 +375,62 @@ func (s *server) dealInsertWithVector(ctx *fasthttp.RequestCtx) {
 		if n > 5000 {
 			n = 5000
 		}
-		xbs, xids, iargs, cargs, err := s.convertWithVector(ts[:n], attrs)
+		if isV {
+			xbs, xids, iargs, nargs, cargs, err = s.convertWithVector(ts[:n], attrs, md.XidLayout())
+		} else {
+			rs, xbs, xids, iargs, nargs, cargs, err = s.convert(ts[:n], attrs, md.XidLayout())
+		}
 		if err != nil {
-			ctx.Response.SetStatusCode(400)
-			ctx.Write([]byte(err.Error()))
-			return
+			return nil, 400, err
 		}
+		rids = append(rids, rs...)
 		{
-			query := fmt.Sprintf("insert into %s (", id)
-			for i, attr := range attrs {
-				if i == 0 {
-					query += fmt.Sprintf("%s", attr.Name)
-				} else {
-					query += fmt.Sprintf(", %s", attr.Name)
-				}
-			}
-			query += ") VALUES"
+			query := dialect.Insert(id, attrs, len(cargs))
 			{
-				s.log.Debugf("convert ok %v: %v -> %v\n", query, n, len(cargs))
-			}
-			for _, _ = range cargs {
-				query += " ("
-				for i := range attrs {
-					if i == 0 {
-						query += "?"
-					} else {
-						query += ", ?"
-					}
-				}
-				query += ")"
+				s.log.Debugf("convert ok %v: %v -> %v\n", id, n, len(cargs))
 			}
 			cli, err := client.New(s.dsn)
 			if err != nil {
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
+				return nil, 500, err
 			}
 			if err := cli.Exec(query, cargs); err != nil {
 				cli.Close()
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
+				return nil, 500, err
 			}
 			cli.Close()
 		}
 		{
-			if err := r.AddTuples(iargs); err != nil {
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
+			if err := r.AddTuples(iargs, nargs); err != nil {
+				return nil, 500, err
 			}
 		}
 		{
 			{
 				s.log.Debugf("xbs: %v, xids: %v\n", len(xbs), len(xids))
 			}
-			if err := s.b.Add(xbs, xids); err != nil {
-				ctx.Response.SetStatusCode(500)
-				ctx.Write([]byte(err.Error()))
-				return
+			if err := addVectors(s.b.WithLayout(md.XidLayout()), xbs, xids, isU); err != nil {
+				return nil, 500, err
 			}
 		}
 		ts = ts[n:]
 	}
-	ctx.Write([]byte(fmt.Sprintf("success")))
+	return rids, 200, nil
+}
+
+func addVectors(b bv.BV, xbs []float32, xids []int64, isU bool) error {
+	if isU {
+		return b.Replace(xbs, xids)
+	}
+	return b.Add(xbs, xids)
 }
 
-func (s *server) convert(ts [][]string, attrs []metadata.Attribute) ([]string, []float32, []int64, []interface{}, [][]interface{}, error) {
+func (s *server) convert(ts [][]string, attrs []metadata.Attribute, l metadata.Layout) ([]string, []float32, []int64, []interface{}, []*roaring.Bitmap, [][]interface{}, error) {
 	var rids []string // removed id list
 
 	xbs := make([]float32, 0, len(ts))
 	xids := make([]int64, 0, len(ts))
 	iargs := make([]interface{}, len(attrs))
+	nargs := make([]*roaring.Bitmap, len(attrs))
 	cargs := make([][]interface{}, 0, len(ts))
 	{
 		for i := range attrs {
@@ -452,10 +451,10 @@ func (s *server) convert(ts [][]string, attrs []metadata.Attribute) ([]string, [
 	for i, ft := range fts {
 		select {
 		case r := <-ft.ch:
-			if xb := r.Result().([]float32); len(xb) != 512 {
+			if xb := r.Result().([]float32); len(xb) != s.cfg.Dim {
 				mp[i] = nil
 				rids = append(rids, ts[i][0])
-				s.log.Debugf("uid = %s: vector not 512: %v\n", ts[i][0], len(xb))
+				s.log.Debugf("uid = %s: dimension of vector is %v, expected %v\n", ts[i][0], len(xb), s.cfg.Dim)
 			} else {
 				xbs = append(xbs, xb...)
 			}
@@ -470,9 +469,12 @@ func (s *server) convert(ts [][]string, attrs []metadata.Attribute) ([]string, [
 		}
 		arg := make([]interface{}, len(attrs))
 		for i, attr := range attrs {
-			v, rs, err := appendSlice(iargs[i], attr.Type, t[i])
+			v, rs, err := appendAttribute(iargs[i], attr, t[i], arg[0], &nargs[i])
 			if err != nil {
-				return nil, nil, nil, nil, nil, err
+				return nil, nil, nil, nil, nil, nil, err
+			}
+			if err := checkDim(attr, v); err != nil {
+				return nil, nil, nil, nil, nil, nil, err
 			}
 			arg[i] = v
 			iargs[i] = rs
@@ -480,15 +482,19 @@ func (s *server) convert(ts [][]string, attrs []metadata.Attribute) ([]string, [
 				xids = append(xids, int64(v.(uint64)))
 			}
 		}
+		if err := l.Check(arg[0].(uint64), xids[len(xids)-1]); err != nil {
+			return nil, nil, nil, nil, nil, nil, err
+		}
 		cargs = append(cargs, arg)
 	}
-	return rids, xbs, xids, iargs, cargs, nil
+	return rids, xbs, xids, iargs, nargs, cargs, nil
 }
 
-func (s *server) convertWithVector(ts [][]string, attrs []metadata.Attribute) ([]float32, []int64, []interface{}, [][]interface{}, error) {
-	xbs := make([]float32, 0, len(ts)*512)
+func (s *server) convertWithVector(ts [][]string, attrs []metadata.Attribute, l metadata.Layout) ([]float32, []int64, []interface{}, []*roaring.Bitmap, [][]interface{}, error) {
+	xbs := make([]float32, 0, len(ts)*s.cfg.Dim)
 	xids := make([]int64, 0, len(ts))
 	iargs := make([]interface{}, len(attrs))
+	nargs := make([]*roaring.Bitmap, len(attrs))
 	cargs := make([][]interface{}, 0, len(ts))
 	{
 		for i := range attrs {
@@ -499,16 +505,22 @@ func (s *server) convertWithVector(ts [][]string, attrs []metadata.Attribute) ([
 		var vec []float32
 
 		if err := json.Unmarshal([]byte(t[len(t)-1]), &vec); err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, err
+		}
+		if len(vec) != s.cfg.Dim {
+			return nil, nil, nil, nil, nil, fmt.Errorf("uid = %s: dimension of vector is %v, expected %v", t[0], len(vec), s.cfg.Dim)
 		}
 		xbs = append(xbs, vec...)
 	}
 	for _, t := range ts {
 		arg := make([]interface{}, len(attrs))
 		for i, attr := range attrs {
-			v, rs, err := appendSlice(iargs[i], attr.Type, t[i])
+			v, rs, err := appendAttribute(iargs[i], attr, t[i], arg[0], &nargs[i])
 			if err != nil {
-				return nil, nil, nil, nil, err
+				return nil, nil, nil, nil, nil, err
+			}
+			if err := checkDim(attr, v); err != nil {
+				return nil, nil, nil, nil, nil, err
 			}
 			arg[i] = v
 			iargs[i] = rs
@@ -516,19 +528,22 @@ func (s *server) convertWithVector(ts [][]string, attrs []metadata.Attribute) ([
 				xids = append(xids, int64(v.(uint64)))
 			}
 		}
+		if err := l.Check(arg[0].(uint64), xids[len(xids)-1]); err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
 		cargs = append(cargs, arg)
 	}
-	return xbs, xids, iargs, cargs, nil
+	return xbs, xids, iargs, nargs, cargs, nil
 }
 
-func (s *server) extractParameters(ctx *fasthttp.RequestCtx) (string, []float32, error) {
+func (s *server) extractParameters(ctx *fasthttp.RequestCtx) (string, []value.Value, []float32, []op.Vector, error) {
 	var typ string
 	var body []byte
 	var mp map[string]interface{}
 
 	form, err := ctx.MultipartForm()
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, nil, err
 	}
 	fs := make(map[string]*request.Part)
 	for k, v := range form.File {
@@ -538,12 +553,12 @@ func (s *server) extractParameters(ctx *fasthttp.RequestCtx) (string, []float32,
 			}
 			fp, err := h.Open()
 			if err != nil {
-				return "", nil, err
+				return "", nil, nil, nil, err
 			}
 			data, err := ioutil.ReadAll(fp)
 			if err != nil {
 				fp.Close()
-				return "", nil, err
+				return "", nil, nil, nil, err
 			}
 			fp.Close()
 			body = append(body, data...)
@@ -551,7 +566,7 @@ func (s *server) extractParameters(ctx *fasthttp.RequestCtx) (string, []float32,
 		if len(body) > 0 {
 			if typ == "application/json" {
 				if err := json.Unmarshal(body, &mp); err != nil {
-					return "", nil, err
+					return "", nil, nil, nil, err
 				}
 			} else {
 				fs[k] = &request.Part{Typ: typ, Data: body}
@@ -561,39 +576,45 @@ func (s *server) extractParameters(ctx *fasthttp.RequestCtx) (string, []float32,
 	}
 	qr, err := s.getSqlQuery(mp)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, nil, err
 	}
-	vec, err := s.vec.GetVector(fs)
+	args, err := getArgs(mp)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, nil, err
 	}
-	return qr, vec, nil
+	var xbs map[string][]float32
+	if len(fs) > 0 { // the pictures are optional
+		if xbs, err = s.vec.GetVectors(fs); err != nil {
+			return "", nil, nil, nil, err
+		}
+	}
+	qr, vec, vs, err := s.queryVectors(qr, mp, xbs, true)
+	return qr, args, vec, vs, err
 }
 
-func (s *server) extractParametersWithVector(ctx *fasthttp.RequestCtx) (string, []float32, error) {
+func (s *server) extractParametersWithVector(ctx *fasthttp.RequestCtx) (string, []value.Value, []float32, []op.Vector, error) {
 	var typ string
-	var data []byte
 	var body []byte
-	var vec []float32
 	var mp map[string]interface{}
 
 	form, err := ctx.MultipartForm()
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, nil, err
 	}
-	for _, v := range form.File {
+	xbs := make(map[string][]float32)
+	for k, v := range form.File {
 		for i, h := range v {
 			if i == 0 {
 				typ = h.Header.Get("Content-Type")
 			}
 			fp, err := h.Open()
 			if err != nil {
-				return "", nil, err
+				return "", nil, nil, nil, err
 			}
 			data, err := ioutil.ReadAll(fp)
 			if err != nil {
 				fp.Close()
-				return "", nil, err
+				return "", nil, nil, nil, err
 			}
 			fp.Close()
 			body = append(body, data...)
@@ -601,28 +622,119 @@ func (s *server) extractParametersWithVector(ctx *fasthttp.RequestCtx) (string,
 		if len(body) > 0 {
 			if typ == "application/json" {
 				if err := json.Unmarshal(body, &mp); err != nil {
-					return "", nil, err
+					return "", nil, nil, nil, err
 				}
 			} else {
-				data = body
+				var xb []float32
+
+				if err := json.Unmarshal(body, &xb); err != nil {
+					return "", nil, nil, nil, err
+				}
+				xbs[k] = xb
 			}
 			body = []byte{}
 		}
 	}
 	qr, err := s.getSqlQuery(mp)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, nil, err
 	}
-	if err := json.Unmarshal(data, &vec); err != nil {
-		return "", nil, err
+	args, err := getArgs(mp)
+	if err != nil {
+		return "", nil, nil, nil, err
 	}
-	return qr, vec, nil
+	qr, vec, vs, err := s.queryVectors(qr, mp, xbs, false)
+	return qr, args, vec, vs, err
+}
+
+// queryVectors combines the vectors by weights into one query vector, and
+// returns the weighted vectors too if they are fused by reciprocal rank.
+// The vectors are optional, since only the vector search needs them.
+func (s *server) queryVectors(qr string, mp map[string]interface{}, xbs map[string][]float32, isN bool) (string, []float32, []op.Vector, error) {
+	var vs []op.Vector
+
+	if len(xbs) == 0 {
+		return qr, nil, nil, nil
+	}
+	ws, err := getWeights(mp)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	fusion, err := getString("fusion", mp)
+	switch {
+	case err != nil || fusion == "combine":
+	case fusion == "rrf":
+		ks := make([]string, 0, len(xbs))
+		for k := range xbs {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks) // the order of fusion is stable between runs
+		for _, k := range ks {
+			w, ok := ws[k]
+			if !ok {
+				w = 1
+			}
+			vs = append(vs, op.Vector{W: w, Xb: xbs[k]})
+		}
+	default:
+		return "", nil, nil, fmt.Errorf("unsupport fusion '%s'", fusion)
+	}
+	if !isN && len(xbs) == 1 && len(ws) == 0 {
+		for _, xb := range xbs {
+			return qr, xb, vs, nil
+		}
+	}
+	vec, err := vector.Combine(xbs, ws)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return qr, vec, vs, nil
 }
 
 func (s *server) getSqlQuery(mp map[string]interface{}) (string, error) {
 	return getString("query", mp)
 }
 
+// build builds the query, a query with arguments is prepared once and
+// executed with the arguments of every request.
+func (s *server) build(qr string, args []value.Value) (*op.OP, error) {
+	if len(args) == 0 {
+		return build.New(qr, s.ctx, s.stg).Build()
+	}
+	s.Lock()
+	v, ok := s.stmts.Get(qr)
+	s.Unlock()
+	if ok {
+		return v.(*build.Stmt).Execute(args)
+	}
+	stmt, err := build.Prepare(qr, s.ctx, s.stg)
+	if err != nil {
+		return nil, err
+	}
+	s.Lock()
+	s.stmts.Add(qr, stmt)
+	s.Unlock()
+	return stmt.Execute(args)
+}
+
+// appendAttribute appends the field s of attribute attr, the null field
+// is appended as the zero value and the row uid is recorded by np. The
+// empty field is null if attr is nullable and not a string.
+func appendAttribute(vs interface{}, attr metadata.Attribute, s string, uid interface{}, np **roaring.Bitmap) (interface{}, interface{}, error) {
+	switch {
+	case attr.Nullable && (s == client.NullField || (len(s) == 0 && attr.Type != types.T_string)):
+		if *np == nil {
+			*np = roaring.NewBitmap()
+		}
+		(*np).Add(uid.(uint64))
+		rs := reflect.ValueOf(vs)
+		return nil, reflect.Append(rs, reflect.Zero(rs.Type().Elem())).Interface(), nil
+	case len(s) == 0 && attr.Type != types.T_string:
+		return nil, nil, fmt.Errorf("attribute '%s' is not nullable", attr.Name)
+	}
+	return appendSlice(vs, attr.Type, s)
+}
+
 func appendSlice(vs interface{}, typ uint32, s string) (interface{}, interface{}, error) {
 	switch typ {
 	case types.T_int8:
@@ -717,11 +829,29 @@ func appendSlice(vs interface{}, typ uint32, s string) (interface{}, interface{}
 		}
 		t := value.MustBeTimestamp(v).Unix()
 		rs = append(rs, t)
-		return t - 8*3600, rs, nil
+		return t, rs, nil
+	case types.T_vector:
+		rs := vs.([]float32)
+		v, err := value.ParseVector(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		rs = append(rs, value.MustBeVector(v)...)
+		return value.MustBeVector(v), rs, nil
 	}
 	return nil, nil, nil
 }
 
+func checkDim(attr metadata.Attribute, v interface{}) error {
+	if attr.Type != types.T_vector {
+		return nil
+	}
+	if n := len(v.([]float32)); n != attr.Dim {
+		return fmt.Errorf("dimension of '%s' is %v, expected %v", attr.Name, n, attr.Dim)
+	}
+	return nil
+}
+
 func newSlice(typ uint32, size int) interface{} {
 	switch typ {
 	case types.T_int8:
@@ -748,38 +878,75 @@ func newSlice(typ uint32, size int) interface{} {
 		return make([]string, 0, size)
 	case types.T_timestamp:
 		return make([]int64, 0, size)
+	case types.T_vector:
+		return make([]float32, 0, size)
 	}
 	return nil
 }
 
-func stringToType(name string) (string, uint32) {
-	switch strings.ToLower(name) {
-	case "int8":
-		return "Uint8", types.T_int8
-	case "int16":
-		return "Int16", types.T_int16
-	case "int32":
-		return "Int32", types.T_int32
-	case "int64":
-		return "Int64", types.T_int64
-	case "uint8":
-		return "UInt8", types.T_uint8
-	case "uint16":
-		return "UInt16", types.T_uint16
-	case "uint32":
-		return "UInt32", types.T_uint32
-	case "uint64":
-		return "UInt64", types.T_uint64
-	case "float32":
-		return "Float32", types.T_float32
-	case "float64":
-		return "Float64", types.T_float64
-	case "string":
-		return "String", types.T_string
-	case "datetime":
-		return "Datetime", types.T_timestamp
-	}
-	return "", 0
+// getWeights returns the weights of query vectors, such as
+// {"weights": {"a": 1, "b": -0.5}}
+func getWeights(mp map[string]interface{}) (map[string]float32, error) {
+	v, ok := mp["weights"]
+	if !ok {
+		return nil, nil
+	}
+	xs, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("Not Object")
+	}
+	ws := make(map[string]float32)
+	for k, x := range xs {
+		w, ok := x.(float64)
+		if !ok {
+			return nil, fmt.Errorf("weight of '%s' is not number", k)
+		}
+		ws[k] = float32(w)
+	}
+	return ws, nil
+}
+
+// getArgs returns the arguments of placeholders, such as
+// {"args": [18, "上海"]}
+func getArgs(mp map[string]interface{}) ([]value.Value, error) {
+	v, ok := mp["args"]
+	if !ok {
+		return nil, nil
+	}
+	xs, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("Not Array")
+	}
+	args := make([]value.Value, len(xs))
+	for i, x := range xs {
+		switch y := x.(type) {
+		case nil:
+			args[i] = value.ConstNull
+		case bool:
+			args[i] = value.NewBool(y)
+		case string:
+			args[i] = value.NewString(y)
+		case float64:
+			if y == math.Trunc(y) && math.Abs(y) < 1<<53 {
+				args[i] = value.NewInt(int64(y))
+			} else {
+				args[i] = value.NewFloat(y)
+			}
+		case []interface{}:
+			vs := make([]float32, len(y))
+			for j, z := range y {
+				f, ok := z.(float64)
+				if !ok {
+					return nil, fmt.Errorf("argument %v is not vector", i+1)
+				}
+				vs[j] = float32(f)
+			}
+			args[i] = value.NewVector(vs)
+		default:
+			return nil, fmt.Errorf("unsupport argument %v", i+1)
+		}
+	}
+	return args, nil
 }
 
 func getString(k string, mp map[string]interface{}) (string, error) {
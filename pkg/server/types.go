@@ -1,7 +1,10 @@
 package server
 
 import (
+	"sync"
+
 	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/lru"
 	"github.com/deepfabric/vectorsql/pkg/request"
 	"github.com/deepfabric/vectorsql/pkg/routines"
 	"github.com/deepfabric/vectorsql/pkg/routines/task"
@@ -10,6 +13,7 @@ import (
 	"github.com/deepfabric/vectorsql/pkg/vector"
 	"github.com/deepfabric/vectorsql/pkg/vm/bv"
 	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
 	"github.com/valyala/fasthttp"
 )
 
@@ -19,19 +23,23 @@ type Server interface {
 }
 
 type Attribute struct {
-	Name  string `json:"name"`
-	Type  string `json:"type"`
-	Index bool   `json:"index"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Index    bool   `json:"index"`
+	Nullable bool   `json:"nullable"`
 }
 
 type Create struct {
-	Name  string      `json:"name"`
-	Item  []Attribute `json:"item"`
-	Event []Attribute `json:"event"`
+	Name    string      `json:"name"`
+	Item    []Attribute `json:"item"`
+	Event   []Attribute `json:"event"`
+	UidBits int         `json:"uid_bits"` // number of bits of uid in xid
+	PidBits int         `json:"pid_bits"` // number of bits of sub-id of vector in xid
 }
 
 type Config struct {
 	B   bv.BV
+	Cfg *op.Config
 	Log logger.Log
 	Cli client.Client
 	Vec vector.Vector
@@ -51,15 +59,22 @@ type faceResult struct {
 	xb  []float32
 }
 
+// MaxStmts is the maximum number of prepared statements cached by server,
+// the least recently used ones are evicted.
+const MaxStmts = 1024
+
 type server struct {
-	port int
-	b    bv.BV
-	dsn  string
-	log  logger.Log
-	cli  client.Client
-	vec  vector.Vector
-	ctx  context.Context
-	stg  storage.Storage
-	srv  *fasthttp.Server
-	rts  routines.Routines
+	sync.Mutex
+	port  int
+	b     bv.BV
+	dsn   string
+	cfg   *op.Config
+	log   logger.Log
+	cli   client.Client
+	vec   vector.Vector
+	ctx   context.Context
+	stg   storage.Storage
+	srv   *fasthttp.Server
+	rts   routines.Routines
+	stmts lru.LRU // prepared statements keyed by query
 }
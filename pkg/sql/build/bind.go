@@ -0,0 +1,533 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// binder replaces the placeholders by the arguments, the statement
+// is copied since the build rewrites it.
+type binder struct {
+	n    int    // number of placeholders
+	id   string // relation of the current select
+	c    context.Context
+	args []value.Value
+}
+
+// bind returns a copy of n whose placeholders are replaced by args, an
+// argument compared with an attribute is checked against the type of
+// attribute.
+func bind(c context.Context, n *tree.Select, args []value.Value) (*tree.Select, error) {
+	bd := &binder{c: c, args: args}
+	r, err := bd.bindSelect(n)
+	if err != nil {
+		return nil, err
+	}
+	if bd.n != len(args) {
+		return nil, fmt.Errorf("expected %v arguments, got %v", bd.n, len(args))
+	}
+	return r, nil
+}
+
+func (bd *binder) bindSelect(n *tree.Select) (*tree.Select, error) {
+	var err error
+
+	r := &tree.Select{}
+	if n.Limit != nil {
+		r.Limit = &tree.Limit{}
+		if r.Limit.Count, err = bd.bindExpr(n.Limit.Count); err != nil {
+			return nil, err
+		}
+		if r.Limit.Offset, err = bd.bindExpr(n.Limit.Offset); err != nil {
+			return nil, err
+		}
+	}
+	if r.Relation, err = bd.bindRelation(n.Relation); err != nil {
+		return nil, err
+	}
+	switch t := n.Order.(type) {
+	case tree.OrderBy:
+		if r.Order, err = bd.bindOrderBy(t); err != nil {
+			return nil, err
+		}
+	case *tree.Top:
+		top := &tree.Top{}
+		if top.N, err = bd.bindExpr(t.N); err != nil {
+			return nil, err
+		}
+		if top.R, err = bd.bindExpr(t.R); err != nil {
+			return nil, err
+		}
+		if top.Order, err = bd.bindOrderBy(t.Order); err != nil {
+			return nil, err
+		}
+		r.Order = top
+	case *tree.Ftop:
+		top := &tree.Ftop{}
+		if top.N, err = bd.bindExpr(t.N); err != nil {
+			return nil, err
+		}
+		if top.Order, err = bd.bindOrderBy(t.Order); err != nil {
+			return nil, err
+		}
+		r.Order = top
+	}
+	return r, nil
+}
+
+func (bd *binder) bindOrderBy(n tree.OrderBy) (tree.OrderBy, error) {
+	if n == nil {
+		return nil, nil
+	}
+	r := make(tree.OrderBy, len(n))
+	for i, o := range n {
+		e, err := bd.bindExpr(o.E)
+		if err != nil {
+			return nil, err
+		}
+		r[i] = &tree.Order{Type: o.Type, E: e}
+	}
+	return r, nil
+}
+
+func (bd *binder) bindRelation(n tree.RelationStatement) (tree.RelationStatement, error) {
+	switch t := n.(type) {
+	case *tree.SelectClause:
+		return bd.bindSelectClause(t)
+	case *tree.JoinClause:
+		left, err := bd.bindRelation(t.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := bd.bindRelation(t.Right)
+		if err != nil {
+			return nil, err
+		}
+		r := &tree.JoinClause{Type: t.Type, Cond: t.Cond, Left: left, Right: right}
+		if cond, ok := t.Cond.(*tree.OnJoinCond); ok {
+			e, err := bd.bindExpr(cond.E)
+			if err != nil {
+				return nil, err
+			}
+			r.Cond = &tree.OnJoinCond{E: e}
+		}
+		return r, nil
+	case *tree.UnionClause:
+		left, err := bd.bindRelation(t.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := bd.bindRelation(t.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &tree.UnionClause{All: t.All, Type: t.Type, Left: left, Right: right}, nil
+	case *tree.AliasedSelect:
+		sel, err := bd.bindSelect(t.Sel)
+		if err != nil {
+			return nil, err
+		}
+		return &tree.AliasedSelect{Sel: sel, As: t.As}, nil
+	case *tree.AliasedTable:
+		return bd.bindAliasedTable(t)
+	case *tree.TableName:
+		return bd.bindTableName(t)
+	}
+	return nil, fmt.Errorf("unknown relation statement '%s'", n)
+}
+
+func (bd *binder) bindSelectClause(n *tree.SelectClause) (*tree.SelectClause, error) {
+	var err error
+
+	id := bd.id
+	defer func() { bd.id = id }()
+	r := &tree.SelectClause{Distinct: n.Distinct}
+	if n.From != nil {
+		r.From = &tree.From{Tables: make(tree.TableStatements, len(n.From.Tables))}
+		for i, t := range n.From.Tables {
+			if r.From.Tables[i], err = bd.bindTable(t); err != nil {
+				return nil, err
+			}
+		}
+		bd.id = tableId(n.From)
+	}
+	for _, sel := range n.Sel {
+		e, err := bd.bindExpr(sel.E)
+		if err != nil {
+			return nil, err
+		}
+		r.Sel = append(r.Sel, &tree.SelectExpr{As: sel.As, E: e})
+	}
+	if r.Where, err = bd.bindWhere(n.Where); err != nil {
+		return nil, err
+	}
+	if r.Having, err = bd.bindWhere(n.Having); err != nil {
+		return nil, err
+	}
+	if n.GroupBy != nil {
+		r.GroupBy = &tree.GroupBy{Es: make(tree.ExprStatements, len(n.GroupBy.Es))}
+		for i, e := range n.GroupBy.Es {
+			if r.GroupBy.Es[i], err = bd.bindExpr(e); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+func (bd *binder) bindWhere(n *tree.Where) (*tree.Where, error) {
+	if n == nil {
+		return nil, nil
+	}
+	e, err := bd.bindExpr(n.E)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.Where{Type: n.Type, E: e}, nil
+}
+
+func (bd *binder) bindTable(n tree.TableStatement) (tree.TableStatement, error) {
+	switch t := n.(type) {
+	case *tree.AliasedTable:
+		return bd.bindAliasedTable(t)
+	case *tree.TableName:
+		return bd.bindTableName(t)
+	case *tree.Subquery:
+		return bd.bindSubquery(t)
+	case *tree.JoinTable:
+		left, err := bd.bindTable(t.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := bd.bindTable(t.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &tree.JoinTable{Type: t.Type, Using: t.Using, Left: left, Right: right}, nil
+	}
+	return nil, fmt.Errorf("illegal table '%s'", n)
+}
+
+func (bd *binder) bindAliasedTable(n *tree.AliasedTable) (*tree.AliasedTable, error) {
+	tbl, err := bd.bindTable(n.Tbl)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.AliasedTable{As: n.As, Tbl: tbl}, nil
+}
+
+func (bd *binder) bindTableName(n *tree.TableName) (*tree.TableName, error) {
+	ns, err := bd.bindColumn(n.N)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.TableName{N: ns}, nil
+}
+
+func (bd *binder) bindSubquery(n *tree.Subquery) (*tree.Subquery, error) {
+	sel, err := bd.bindSelect(n.Select)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.Subquery{Exists: n.Exists, Select: sel}, nil
+}
+
+func (bd *binder) bindColumn(ns tree.ColunmNameList) (tree.ColunmNameList, error) {
+	var err error
+
+	r := make(tree.ColunmNameList, len(ns))
+	for i, n := range ns {
+		r[i].Path = n.Path
+		if n.Index != nil {
+			if r[i].Index, err = bd.bindExpr(n.Index); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+func (bd *binder) bindExpr(n tree.ExprStatement) (tree.ExprStatement, error) {
+	if n == nil {
+		return nil, nil
+	}
+	switch e := n.(type) {
+	case *tree.Placeholder:
+		v, err := bd.arg(e)
+		if err != nil {
+			return nil, err
+		}
+		return &tree.Value{E: v}, nil
+	case tree.ColunmNameList:
+		return bd.bindColumn(e)
+	case *tree.Subquery:
+		return bd.bindSubquery(e)
+	case *tree.NotExpr:
+		x, err := bd.bindExpr(e.E)
+		return &tree.NotExpr{E: x}, err
+	case *tree.UnaryMinusExpr:
+		x, err := bd.bindExpr(e.E)
+		return &tree.UnaryMinusExpr{E: x}, err
+	case *tree.IsNullExpr:
+		x, err := bd.bindExpr(e.E)
+		return &tree.IsNullExpr{E: x}, err
+	case *tree.IsNotNullExpr:
+		x, err := bd.bindExpr(e.E)
+		return &tree.IsNotNullExpr{E: x}, err
+	case *tree.ParenExpr:
+		x, err := bd.bindExpr(e.E)
+		return &tree.ParenExpr{E: x}, err
+	case *tree.OrExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.OrExpr{Left: x, Right: y}, err
+	case *tree.AndExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.AndExpr{Left: x, Right: y}, err
+	case *tree.DivExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.DivExpr{Left: x, Right: y}, err
+	case *tree.ModExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.ModExpr{Left: x, Right: y}, err
+	case *tree.MultExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.MultExpr{Left: x, Right: y}, err
+	case *tree.PlusExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.PlusExpr{Left: x, Right: y}, err
+	case *tree.MinusExpr:
+		x, y, err := bd.bindExprs(e.Left, e.Right)
+		return &tree.MinusExpr{Left: x, Right: y}, err
+	case *tree.InExpr:
+		x, y, err := bd.bindIn(e.Left, e.Right)
+		return &tree.InExpr{Left: x, Right: y}, err
+	case *tree.NotInExpr:
+		x, y, err := bd.bindIn(e.Left, e.Right)
+		return &tree.NotInExpr{Left: x, Right: y}, err
+	case *tree.EqExpr:
+		x, y, err := bd.bindCompare(e.Left, e.Right)
+		return &tree.EqExpr{Left: x, Right: y}, err
+	case *tree.NeExpr:
+		x, y, err := bd.bindCompare(e.Left, e.Right)
+		return &tree.NeExpr{Left: x, Right: y}, err
+	case *tree.LtExpr:
+		x, y, err := bd.bindCompare(e.Left, e.Right)
+		return &tree.LtExpr{Left: x, Right: y}, err
+	case *tree.LeExpr:
+		x, y, err := bd.bindCompare(e.Left, e.Right)
+		return &tree.LeExpr{Left: x, Right: y}, err
+	case *tree.GtExpr:
+		x, y, err := bd.bindCompare(e.Left, e.Right)
+		return &tree.GtExpr{Left: x, Right: y}, err
+	case *tree.GeExpr:
+		x, y, err := bd.bindCompare(e.Left, e.Right)
+		return &tree.GeExpr{Left: x, Right: y}, err
+	case *tree.BetweenExpr:
+		x, from, to, err := bd.bindBetween(e.E, e.From, e.To)
+		return &tree.BetweenExpr{E: x, From: from, To: to}, err
+	case *tree.NotBetweenExpr:
+		x, from, to, err := bd.bindBetween(e.E, e.From, e.To)
+		return &tree.NotBetweenExpr{E: x, From: from, To: to}, err
+	case *tree.FuncExpr:
+		es := make(tree.ExprStatements, len(e.Es))
+		for i := range e.Es {
+			x, err := bd.bindExpr(e.Es[i])
+			if err != nil {
+				return nil, err
+			}
+			es[i] = x
+		}
+		return &tree.FuncExpr{Name: e.Name, Es: es}, nil
+	case *tree.CaseExpr:
+		return bd.bindCase(e)
+	case *tree.IntervalExpr:
+		return bindInterval(e)
+	}
+	return n, nil
+}
+
+func (bd *binder) bindCase(n *tree.CaseExpr) (tree.ExprStatement, error) {
+	var err error
+
+	e := &tree.CaseExpr{Whens: make([]*tree.When, len(n.Whens))}
+	if n.E != nil {
+		if e.E, err = bd.bindExpr(n.E); err != nil {
+			return nil, err
+		}
+	}
+	for i, w := range n.Whens {
+		x, y, err := bd.bindExprs(w.Cond, w.Val)
+		if err != nil {
+			return nil, err
+		}
+		e.Whens[i] = &tree.When{Cond: x, Val: y}
+	}
+	if n.Else != nil {
+		if e.Else, err = bd.bindExpr(n.Else); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (bd *binder) bindExprs(left, right tree.ExprStatement) (tree.ExprStatement, tree.ExprStatement, error) {
+	x, err := bd.bindExpr(left)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := bd.bindExpr(right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return x, y, nil
+}
+
+func (bd *binder) bindCompare(left, right tree.ExprStatement) (tree.ExprStatement, tree.ExprStatement, error) {
+	x, err := bd.bindOperand(left, right)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := bd.bindOperand(right, left)
+	if err != nil {
+		return nil, nil, err
+	}
+	return x, y, nil
+}
+
+// bindIn binds the subquery or the list of values of in, the values are
+// compared with n.
+func (bd *binder) bindIn(n, list tree.ExprStatement) (tree.ExprStatement, tree.ExprStatement, error) {
+	x, err := bd.bindExpr(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	es, ok := list.(tree.ExprStatements)
+	if !ok {
+		y, err := bd.bindExpr(list)
+		if err != nil {
+			return nil, nil, err
+		}
+		return x, y, nil
+	}
+	ys := make(tree.ExprStatements, len(es))
+	for i, e := range es {
+		if ys[i], err = bd.bindOperand(e, n); err != nil {
+			return nil, nil, err
+		}
+	}
+	return x, ys, nil
+}
+
+func (bd *binder) bindBetween(n, from, to tree.ExprStatement) (tree.ExprStatement, tree.ExprStatement, tree.ExprStatement, error) {
+	x, err := bd.bindExpr(n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	y, err := bd.bindOperand(from, n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	z, err := bd.bindOperand(to, n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return x, y, z, nil
+}
+
+// bindOperand binds the operand n which is compared with m, if n is a
+// placeholder and m is an attribute, the argument must match the type
+// of attribute.
+func (bd *binder) bindOperand(n, m tree.ExprStatement) (tree.ExprStatement, error) {
+	p, ok := n.(*tree.Placeholder)
+	if !ok {
+		return bd.bindExpr(n)
+	}
+	v, err := bd.arg(p)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := columnName(m)
+	if !ok || len(bd.id) == 0 {
+		return &tree.Value{E: v}, nil
+	}
+	typ, err := bd.c.AttributeType(name, bd.id)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = bindValue(typ, v); err != nil {
+		return nil, fmt.Errorf("argument %s of '%s': %v", p, name, err)
+	}
+	return &tree.Value{E: v}, nil
+}
+
+func (bd *binder) arg(p *tree.Placeholder) (value.Value, error) {
+	if p.Idx >= len(bd.args) {
+		return nil, fmt.Errorf("no value provided for placeholder %s", p)
+	}
+	if p.Idx >= bd.n {
+		bd.n = p.Idx + 1
+	}
+	return bd.args[p.Idx], nil
+}
+
+// bindValue converts the argument v to the value compared with the
+// attribute of type typ.
+func bindValue(typ uint32, v value.Value) (value.Value, error) {
+	switch t := v.ResolvedType(); {
+	case t == types.T_null:
+		return v, nil
+	case typ == types.T_int8, typ == types.T_int16, typ == types.T_int32, typ == types.T_int64,
+		typ == types.T_uint8, typ == types.T_uint16, typ == types.T_uint32, typ == types.T_uint64:
+		if t == types.T_int {
+			return v, nil
+		}
+	case typ == types.T_float32, typ == types.T_float64:
+		switch t {
+		case types.T_int:
+			return value.NewFloat(float64(value.MustBeInt(v))), nil
+		case types.T_float:
+			return v, nil
+		}
+	case typ == types.T_timestamp:
+		switch t {
+		case types.T_int:
+			return v, nil
+		case types.T_string:
+			if _, err := value.ParseTimestamp(value.MustBeString(v)); err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+	case typ == types.T_string, typ == types.T_vector:
+		if uint32(t) == typ {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot use %s %s as %s", v.ResolvedType(), v, types.T(typ))
+}
+
+// tableId returns the relation of from clause, or empty string if the
+// relation is not a table.
+func tableId(n *tree.From) string {
+	if len(n.Tables) != 1 {
+		return ""
+	}
+	t, ok := n.Tables[0].(*tree.AliasedTable)
+	if !ok {
+		return ""
+	}
+	tn, ok := t.Tbl.(*tree.TableName)
+	if !ok {
+		return ""
+	}
+	ns := make([]string, len(tn.N))
+	for i := range tn.N {
+		ns[i] = string(tn.N[i].Path)
+	}
+	return strings.Join(ns, ".")
+}
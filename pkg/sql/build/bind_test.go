@@ -0,0 +1,87 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/client"
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// attributes is a context which knows the types of attributes of t.
+type attributes map[string]uint32
+
+func (as attributes) Client() client.Client                       { return nil }
+func (as attributes) IsIndex(_, _ string) (bool, error)           { return false, nil }
+func (as attributes) AttributeBelong(_, _ string) (string, error) { return "t", nil }
+
+func (as attributes) AttributeType(name, id string) (uint32, error) {
+	if typ, ok := as[name]; ok && id == "t" {
+		return typ, nil
+	}
+	return 0, context.NotExist
+}
+
+func TestBind(t *testing.T) {
+	c := attributes{
+		"age":  types.T_uint8,
+		"name": types.T_string,
+		"ts":   types.T_timestamp,
+		"rate": types.T_float32,
+	}
+	tests := []struct {
+		sql  string
+		args []value.Value
+		want string // empty if the binding fails
+	}{
+		{
+			"select * from t where age = ? and name in (?, ?)",
+			[]value.Value{value.NewInt(3), value.NewString("a"), value.NewString("b")},
+			"SELECT * FROM t WHERE age = 3 AND name IN ('a', 'b')",
+		},
+		{
+			"select * from t where rate > $1 and age < $1",
+			[]value.Value{value.NewInt(3)},
+			"SELECT * FROM t WHERE rate > 3.0 AND age < 3",
+		},
+		{
+			"select * from t where ts between ? and ?",
+			[]value.Value{value.NewString("2020-01-01 00:00:00"), value.NewInt(1577836800)},
+			"SELECT * FROM t WHERE ts BETWEEN '2020-01-01 00:00:00' AND 1577836800",
+		},
+		{
+			"select * from t where name = ?",
+			[]value.Value{value.ConstNull},
+			"SELECT * FROM t WHERE name = null",
+		},
+		{
+			"select * from (select age from t where age = ?) where age > ?",
+			[]value.Value{value.NewInt(3), value.NewString("x")},
+			"SELECT * FROM (SELECT age FROM t WHERE age = 3) WHERE age > 'x'",
+		},
+		{"select * from t where age = ?", []value.Value{value.NewString("3")}, ""},
+		{"select * from t where name = ?", []value.Value{value.NewInt(3)}, ""},
+		{"select * from t where ts = ?", []value.Value{value.NewString("today")}, ""},
+		{"select * from t where rate = ?", []value.Value{value.NewString("1.5")}, ""},
+		{"select * from t where age = ? and name = ?", []value.Value{value.NewInt(3)}, ""},
+		{"select * from t where age = $3", []value.Value{value.NewInt(3), value.NewInt(4)}, ""},
+		{"select * from t where age = ?", []value.Value{value.NewInt(3), value.NewInt(4)}, ""},
+	}
+	for _, test := range tests {
+		n, err := parser.Parse(test.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := bind(c, n, test.args)
+		switch {
+		case len(test.want) == 0 && err == nil:
+			t.Errorf("bind(%s, %v) = %s, want error", test.sql, test.args, r)
+		case len(test.want) > 0 && err != nil:
+			t.Errorf("bind(%s, %v): %v", test.sql, test.args, err)
+		case len(test.want) > 0 && r.String() != test.want:
+			t.Errorf("bind(%s, %v) = %s, want %s", test.sql, test.args, r, test.want)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package build
 
 import (
+	"fmt"
+
 	"github.com/deepfabric/vectorsql/pkg/sql/parser"
 	"github.com/deepfabric/vectorsql/pkg/sql/tree"
 	"github.com/deepfabric/vectorsql/pkg/storage"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
 	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter/intersect"
 	"github.com/deepfabric/vectorsql/pkg/vm/op"
-	"github.com/deepfabric/vectorsql/pkg/vm/opt"
 )
 
 func New(sql string, c context.Context, stg storage.Storage) *build {
@@ -22,61 +25,150 @@ func (b *build) Build() (*op.OP, error) {
 	if err != nil {
 		return nil, err
 	}
+	if n, err = bind(b.c, n, nil); err != nil {
+		return nil, err
+	}
 	return b.buildStatement(n)
 }
 
 func (b *build) buildStatement(n *tree.Select) (*op.OP, error) {
-	var o op.OP
-
-	id, e, sc, err := b.buildRelation(n.Relation)
+	if u, ok := n.Relation.(*tree.UnionClause); ok {
+		return b.buildUnion(n, u)
+	}
+	switch n.Order.(type) {
+	case *tree.Top, *tree.Ftop:
+		b.top = true
+	}
+	id, o, err := b.buildQuery(n.Relation)
 	if err != nil {
 		return nil, err
 	}
+	n.Relation = o.N.Relation
 	o.N = n
+	if n.Order != nil {
+		t, err := b.buildOrder(n, n.Order, id)
+		if err != nil {
+			return nil, err
+		}
+		o.T = t
+	}
+	if b.rd != nil {
+		if o.T == nil {
+			o.T = &op.Top{}
+		}
+		o.T.IsR = true
+		o.T.Radius = *b.rd
+	}
+	if o.T != nil {
+		o.T.IsS = b.sim
+		o.T.IsG = isGrouped(n.Relation.(*tree.SelectClause))
+	}
+	if o.T == nil {
+		if o.A, err = b.buildAggregate(n, id); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// buildQuery builds the filters of relation n, the relation of the
+// returned op has no where clause.
+func (b *build) buildQuery(n tree.RelationStatement) (string, *op.OP, error) {
+	var o op.OP
+
+	b.fs = nil
+	id, e, sc, err := b.buildRelation(n)
+	if err != nil {
+		return "", nil, err
+	}
+	r, err := b.stg.Relation(metadata.Ikey(id))
+	if err != nil {
+		return "", nil, err
+	}
+	o.L = r.Metadata().XidLayout()
 	sc.Where = nil
-	n.Relation = sc
+	o.N = &tree.Select{Relation: sc}
 	if e != nil {
-		c, i, err := opt.New(b.c, b.stg).Optimize(e, id)
+		c, i, err := b.optimize(e, id)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		o.Cf = c
 		o.If = i
 	}
-	if n.Order != nil {
-		t, err := b.buildOrder(n, n.Order)
-		if err != nil {
-			return nil, err
+	if len(b.fs) > 0 {
+		if o.Cf != nil {
+			b.fs = append(b.fs, o.Cf)
 		}
-		o.T = t
+		o.Cf = intersect.New(b.fs)
 	}
-	return &o, nil
+	return id, &o, nil
 }
 
-func (b *build) buildOrder(n *tree.Select, ord tree.OrderStatement) (*op.Top, error) {
+// buildOrder returns the top of ord, the ordering of a range search is
+// applied to its candidates, otherwise the ordering is left to clickhouse.
+func (b *build) buildOrder(n *tree.Select, ord tree.OrderStatement, id string) (*op.Top, error) {
 	switch t := ord.(type) {
+	case tree.OrderBy:
+		if b.rd != nil {
+			n.Order = nil
+			if err := b.buildTopOrder(t, id); err != nil {
+				return nil, err
+			}
+			return &op.Top{Order: t}, nil
+		}
+		for _, o := range t {
+			if err := b.buildFuncs(o.E, id); err != nil {
+				return nil, err
+			}
+		}
 	case *tree.Top:
 		n.Order = nil
+		if err := b.buildTopOrder(t.Order, id); err != nil {
+			return nil, err
+		}
 		return b.buildTop(t)
 	case *tree.Ftop:
 		n.Order = nil
+		if err := b.buildTopOrder(t.Order, id); err != nil {
+			return nil, err
+		}
 		return b.buildFtop(t)
 	}
 	return nil, nil
 }
 
+func (b *build) buildTopOrder(ord tree.OrderBy, id string) error {
+	for _, o := range ord {
+		if err := b.buildRank(o.E, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *build) buildTop(ord *tree.Top) (*op.Top, error) {
-	if n, err := b.buildExprIntConstant(ord.N); err != nil {
+	n, err := b.buildExprIntConstant(ord.N)
+	if err != nil {
 		return nil, err
-	} else {
-		return &op.Top{Num: int(n), IsF: false}, nil
 	}
+	if ord.R == nil {
+		return &op.Top{Num: int(n), IsF: false, Order: ord.Order}, nil
+	}
+	r, err := b.buildExprIntConstant(ord.R)
+	if err != nil {
+		return nil, err
+	}
+	if r < 1 {
+		return nil, fmt.Errorf("illegal oversampling factor '%s'", ord.R)
+	}
+	return &op.Top{Num: int(n), IsF: false, Oversample: int(r), Order: ord.Order}, nil
 }
 
 func (b *build) buildFtop(ord *tree.Ftop) (*op.Top, error) {
 	if n, err := b.buildExprIntConstant(ord.N); err != nil {
 		return nil, err
 	} else {
-		return &op.Top{Num: int(n), IsF: true}, nil
+		return &op.Top{Num: int(n), IsF: true, Order: ord.Order}, nil
 	}
 }
@@ -0,0 +1,122 @@
+package build
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+)
+
+func TestFtop(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	// the uids 4 and 6 match the filter, they are the 4th and 6th nearest
+	sql := "select uid from user where city = 'sh' and age > 30 ftop 2"
+	tests := []struct {
+		limit  int
+		rounds int
+		want   string
+	}{
+		{100, 3, "SELECT uid FROM (WITH [68719476736, 103079215104] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids AND uid IN [4, 6] ORDER BY no)"},
+		{4, 2, "SELECT uid FROM (WITH [68719476736] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids AND uid IN [4] ORDER BY no)"},
+		{2, 1, ""},
+	}
+	for _, test := range tests {
+		qs, o, err := e.query(sql, &op.Config{Dim: 2, FtopLimit: test.limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.T.Rounds != test.rounds {
+			t.Errorf("ftop limit %v runs %v rounds, want %v", test.limit, o.T.Rounds, test.rounds)
+		}
+		switch {
+		case len(test.want) == 0 && len(qs) > 0:
+			t.Errorf("ftop limit %v = %v, want no query", test.limit, qs)
+		case len(test.want) > 0 && (len(qs) == 0 || qs[len(qs)-1] != test.want):
+			t.Errorf("ftop limit %v = %v, want %s", test.limit, qs, test.want)
+		}
+	}
+}
+
+func TestRerank(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql string
+		ns  []int64 // numbers of candidates of the searches
+	}{
+		{"select uid from user top 2", []int64{2}},
+		{"select uid from user top 2 rerank 3", []int64{6, 2}},
+		{"select uid from user where age > 10 top 2 rerank 1", []int64{2, 2}},
+		{"select uid from user top 2 rerank 0", nil},
+		{"select uid from user top 2 rerank 'a'", nil},
+	}
+	for _, test := range tests {
+		qs, _, err := e.query(test.sql, nil)
+		switch {
+		case test.ns == nil && err == nil:
+			t.Errorf("%s = %v, want error", test.sql, qs)
+		case test.ns != nil && err != nil:
+			t.Errorf("%s: %v", test.sql, err)
+		case test.ns != nil && !reflect.DeepEqual(e.vs.ns, test.ns):
+			t.Errorf("%s searches %v candidates, want %v", test.sql, e.vs.ns, test.ns)
+		}
+	}
+}
+
+func TestGroupTop(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	queryTests(t, e, []struct{ sql, want string }{
+		{
+			"select city, count(*) from user group by city top 3",
+			"WITH [17179869184, 34359738368, 51539607552] AS xids SELECT city, count(*) FROM user_item WHERE xid IN xids GROUP BY city ORDER BY min(indexOf(xids, xid))",
+		},
+		{
+			"select city, count(*), max(similarity()) from user group by city having max(similarity()) > 0.1 top 3",
+			"WITH [17179869184, 34359738368, 51539607552] AS xids, [0.5, 0.2, 0.1] AS scores SELECT city, count(*), max(arrayElement(scores, indexOf(xids, xid))) FROM user_item WHERE xid IN xids GROUP BY city HAVING max(arrayElement(scores, indexOf(xids, xid))) > 0.1 ORDER BY min(indexOf(xids, xid))",
+		},
+		{
+			"select city, count(*) from user group by city top 3 order by count(*) desc",
+			"WITH [17179869184, 34359738368, 51539607552] AS xids SELECT city, count(*) FROM user_item WHERE xid IN xids GROUP BY city ORDER BY count(*) DESC, min(indexOf(xids, xid))",
+		},
+		{
+			"select count(*) from user where city = 'sh' top 2",
+			"WITH [34359738368, 68719476736] AS xids SELECT count(*) FROM user_item WHERE xid IN xids ORDER BY min(indexOf(xids, xid))",
+		},
+		{
+			"select city, count(*) from user group by city having count(*) > 1",
+			"SELECT city, count(*) FROM user_item GROUP BY city HAVING count(*) > 1",
+		},
+	})
+}
+
+func TestTopOrder(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	queryTests(t, e, []struct{ sql, want string }{
+		{
+			"select uid, age from user top 3 order by age desc",
+			"SELECT uid, age FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, age FROM user_item WHERE xid IN xids ORDER BY age DESC, no)",
+		},
+		{
+			"select uid, age from user order by age desc top 3",
+			"SELECT uid, age FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, age FROM user_item WHERE xid IN xids ORDER BY age DESC, no)",
+		},
+		{
+			"select uid, age from user order by age desc ftop 3",
+			"SELECT uid, age FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, age FROM user_item WHERE xid IN xids AND uid IN [1, 2, 3] ORDER BY age DESC, no)",
+		},
+		{
+			"select uid from user top 3 order by similarity() desc",
+			"SELECT uid FROM (WITH [17179869184, 34359738368, 51539607552] AS xids, [0.5, 0.2, 0.1] AS scores SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY arrayElement(scores, indexOf(xids, xid)) DESC, no)",
+		},
+		// the candidates of range search are within the squared distance 10
+		{
+			"select uid, age from user where distance(pic) < 10 order by age desc",
+			"SELECT uid, age FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, age FROM user_item WHERE xid IN xids ORDER BY age DESC, no)",
+		},
+		{"select uid from user order by age desc", "SELECT uid FROM user_item ORDER BY age DESC"},
+		{"select uid from user order by similarity() desc", ""},
+	})
+}
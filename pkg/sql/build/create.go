@@ -0,0 +1,243 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// VectorDim is the dimension of the vectors of pictures.
+const VectorDim = 512
+
+func (b *build) buildCreate(n *tree.CreateTable) (*op.Create, error) {
+	var md metadata.Metadata
+
+	name, err := b.buildTableName(n.Table)
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range n.Defs {
+		if d, ok := def.(*tree.ColumnDef); ok {
+			tname, nullable := NullableType(d.Type)
+			cname, typ, dim := AttributeType(tname)
+			if len(cname) == 0 {
+				return nil, TypeError(d.Type)
+			}
+			md.Attrs = append(md.Attrs, metadata.Attribute{Name: string(d.Name), Type: typ, Dim: dim, Nullable: nullable})
+		}
+	}
+	for _, def := range n.Defs {
+		if d, ok := def.(*tree.IndexDef); ok {
+			for _, col := range d.Cols {
+				i := attributeIndex(string(col), md.Attrs)
+				if i < 0 {
+					return nil, fmt.Errorf("attribute '%s' not exist", col)
+				}
+				md.Attrs[i].Index = true
+			}
+		}
+	}
+	opts := DefaultTableOptions
+	for _, o := range n.Options {
+		switch strings.ToLower(string(o.Name)) {
+		case "engine":
+			opts.Engine, err = optionString(o)
+		case "partition":
+			opts.Partition, err = optionString(o)
+		case "order":
+			opts.Order, err = optionString(o)
+		case "uid_bits":
+			md.Layout.UidBits, err = optionInt(o)
+		case "pid_bits":
+			md.Layout.PidBits, err = optionInt(o)
+		case "vector_dim": // the vectors of pictures are given by the vector server
+			var dim int
+
+			if dim, err = optionInt(o); err == nil && dim != VectorDim {
+				err = fmt.Errorf("vector_dim must be %v, got %v", VectorDim, dim)
+			}
+		default:
+			err = fmt.Errorf("unsupport option '%s'", o.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewCreate(name, md, opts)
+}
+
+// NewCreate returns the creation of relation name, the first attributes
+// of md must be uid, xid and pic.
+func NewCreate(name string, md metadata.Metadata, opts TableOptions) (*op.Create, error) {
+	attrs := md.Attrs
+	if len(attrs) < 3 {
+		return nil, errors.New("need at least uid, xid, pic attributes")
+	}
+	if attrs[0].Name != "uid" || attrs[0].Type != types.T_uint64 {
+		return nil, errors.New("need attribute uid(uint64)")
+	}
+	if attrs[1].Name != "xid" || attrs[1].Type != types.T_uint64 {
+		return nil, errors.New("need attribute xid(uint64)")
+	}
+	if attrs[2].Name != "pic" || attrs[2].Type != types.T_string {
+		return nil, errors.New("need attribute pic(string)")
+	}
+	for i, attr := range attrs {
+		if attr.Type == types.T_vector && attr.Index {
+			return nil, fmt.Errorf("vector attribute '%s' cannot be indexed", attr.Name)
+		}
+		if attr.Nullable && (i < 3 || attr.Type == types.T_vector) {
+			return nil, fmt.Errorf("attribute '%s' cannot be nullable", attr.Name)
+		}
+	}
+	if md.Layout.UidBits != 0 || md.Layout.PidBits != 0 {
+		if err := md.Layout.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Engine) == 0 || len(opts.Order) == 0 {
+		return nil, errors.New("need engine and order of table")
+	}
+	for _, o := range []struct{ name, s string }{
+		{"engine", opts.Engine}, {"partition", opts.Partition}, {"order", opts.Order},
+	} {
+		if err := checkOption(o.s); err != nil {
+			return nil, fmt.Errorf("illegal %s '%s': %v", o.name, o.s, err)
+		}
+	}
+	id := metadata.Ikey(name)
+	sql := dialect.CreateTable(id, attrs, opts.Engine, opts.Partition, opts.Order)
+	md.IsE = false
+	return &op.Create{Id: id, Sql: sql, Md: md}, nil
+}
+
+// AttributeType returns the type of clickhouse, the type and the
+// dimension of attribute of the type name, the returned name is empty if
+// the type is unsupported.
+// TypeError returns the error of the unsupported type name. DateTime64 is
+// not supported, since the clickhouse-go in use cannot read it and the
+// index keeps datetimes as unix seconds.
+func TypeError(name string) error {
+	if strings.HasPrefix(strings.ToLower(name), "datetime64") {
+		return fmt.Errorf("unsupport type '%s', the precision of datetime is second", name)
+	}
+	return fmt.Errorf("unsupport type '%s'", name)
+}
+
+func AttributeType(name string) (string, uint32, int) {
+	var typ uint32
+
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "vector(") && strings.HasSuffix(name, ")") {
+		dim, err := strconv.Atoi(strings.TrimSpace(name[7 : len(name)-1]))
+		if err != nil || dim <= 0 {
+			return "", 0, 0
+		}
+		return dialect.Type(types.T_vector), types.T_vector, dim
+	}
+	switch name {
+	case "int8":
+		typ = types.T_int8
+	case "int16":
+		typ = types.T_int16
+	case "int32":
+		typ = types.T_int32
+	case "int64":
+		typ = types.T_int64
+	case "uint8":
+		typ = types.T_uint8
+	case "uint16":
+		typ = types.T_uint16
+	case "uint32":
+		typ = types.T_uint32
+	case "uint64":
+		typ = types.T_uint64
+	case "float32":
+		typ = types.T_float32
+	case "float64":
+		typ = types.T_float64
+	case "string":
+		typ = types.T_string
+	case "datetime":
+		typ = types.T_timestamp
+	default:
+		return "", 0, 0
+	}
+	return dialect.Type(typ), typ, 0
+}
+
+// NullableType returns the type name wrapped by Nullable and true, name
+// itself and false are returned if it is not nullable.
+func NullableType(name string) (string, bool) {
+	if s := strings.ToLower(name); strings.HasPrefix(s, "nullable(") && strings.HasSuffix(s, ")") {
+		return strings.TrimSpace(name[9 : len(name)-1]), true
+	}
+	return name, false
+}
+
+func attributeIndex(name string, attrs []metadata.Attribute) int {
+	for i, attr := range attrs {
+		if attr.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkOption checks the option s which is put into the statement of
+// clickhouse as it is, s can only be made up of names, numbers,
+// operators, balanced parentheses and quoted strings.
+func checkOption(s string) error {
+	var depth int
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			j := strings.IndexAny(s[i+1:], "'\\\n")
+			if j < 0 || s[i+1+j] != '\'' {
+				return errors.New("unterminated string")
+			}
+			i += j + 1
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth < 0 {
+				return errors.New("unbalanced parentheses")
+			}
+		case c == '-' && i+1 < len(s) && s[i+1] == '-', c == '/' && i+1 < len(s) && s[i+1] == '*':
+			return errors.New("comment is not allowed")
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("_ ,.+-*/%", c) >= 0:
+		default:
+			return fmt.Errorf("illegal character '%c'", c)
+		}
+	}
+	if depth != 0 {
+		return errors.New("unbalanced parentheses")
+	}
+	return nil
+}
+
+func optionString(o *tree.Option) (string, error) {
+	v, ok := o.E.E.(*value.String)
+	if !ok {
+		return "", fmt.Errorf("option '%s' is not string", o.Name)
+	}
+	return string(*v), nil
+}
+
+func optionInt(o *tree.Option) (int, error) {
+	v, ok := o.E.E.(*value.Int)
+	if !ok {
+		return 0, fmt.Errorf("option '%s' is not integer", o.Name)
+	}
+	return int(*v), nil
+}
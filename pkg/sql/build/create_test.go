@@ -0,0 +1,86 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+)
+
+func TestCreateOptions(t *testing.T) {
+	md := metadata.Metadata{
+		Attrs: []metadata.Attribute{
+			{Name: "uid", Type: types.T_uint64},
+			{Name: "xid", Type: types.T_uint64},
+			{Name: "pic", Type: types.T_string},
+		},
+	}
+	tests := []struct {
+		opts TableOptions
+		ok   bool
+	}{
+		{DefaultTableOptions, true},
+		{TableOptions{"MergeTree()", "", "(uid, xid)"}, true},
+		{TableOptions{"ReplicatedMergeTree('/clickhouse/tables/{shard}/user', '{replica}')", "uid % 16", "uid"}, true},
+		{TableOptions{"MergeTree()", "toYYYYMM(toDate(xid / 1000))", "(uid)"}, true},
+		{TableOptions{"MergeTree(); drop table user", "", "(uid)"}, false},
+		{TableOptions{"MergeTree()", "", "(uid);"}, false},
+		{TableOptions{"MergeTree()", "uid -- comment", "(uid)"}, false},
+		{TableOptions{"MergeTree()", "uid /* comment */", "(uid)"}, false},
+		{TableOptions{"MergeTree()", "", "(uid"}, false},
+		{TableOptions{"MergeTree()", "", "uid)("}, false},
+		{TableOptions{"MergeTree('a)", "", "(uid)"}, false},
+		{TableOptions{"MergeTree('a\\\\')", "", "(uid)"}, false},
+		{TableOptions{"MergeTree()", "`uid`", "(uid)"}, false},
+		{TableOptions{"", "", "(uid)"}, false},
+	}
+	for _, test := range tests {
+		_, err := NewCreate("user", md, test.opts)
+		if ok := err == nil; ok != test.ok {
+			t.Errorf("NewCreate(%+v): %v, want ok = %v", test.opts, err, test.ok)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql string
+		ok  bool
+	}{
+		{"create table item (uid uint64, xid uint64, pic string, city string, index(city))", true},
+		{"create table item (uid uint64, xid uint64, pic string) with (vector_dim = 512)", true},
+		{"create table item (uid uint64, xid uint64, pic string) with (uid_bits = 32, pid_bits = 32, vector_dim = 512)", true},
+		{"create table item (uid uint64, xid uint64, pic string) with (vector_dim = 128)", false},
+		{"create table item (uid uint64, xid uint64, pic string) with (vector_dim = '512')", false},
+		{"create table item (uid uint64, xid uint64, pic string) with (dim = 512)", false},
+		{"create table item (uid uint64, xid uint64, pic string, index(city))", false},
+		{"create table item (uid uint64, xid uint64, pic string, ts datetime)", true},
+		{"create table item (uid uint64, xid uint64, pic string, ts datetime64)", false},
+	}
+	for _, test := range tests {
+		_, _, err := New(test.sql, e.ctx, e.stg).BuildExec()
+		if ok := err == nil; ok != test.ok {
+			t.Errorf("%s: %v, want ok = %v", test.sql, err, test.ok)
+		}
+	}
+}
+
+func TestTypeError(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"decimal", "unsupport type 'decimal'"},
+		{"datetime64", "unsupport type 'datetime64', the precision of datetime is second"},
+		{"DateTime64(3)", "unsupport type 'DateTime64(3)', the precision of datetime is second"},
+	}
+	for _, test := range tests {
+		if name, _, _ := AttributeType(test.name); len(name) > 0 {
+			t.Errorf("AttributeType(%s) = %s, want unsupported", test.name, name)
+		}
+		if err := TypeError(test.name); err.Error() != test.want {
+			t.Errorf("TypeError(%s) = %v, want %s", test.name, err, test.want)
+		}
+	}
+}
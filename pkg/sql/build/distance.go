@@ -0,0 +1,83 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// buildDistance extracts the range predicates 'distance(x) < r' from the
+// conjunctions of the where clause, and returns the remaining expression.
+func (b *build) buildDistance(n tree.ExprStatement) (tree.ExprStatement, error) {
+	switch e := n.(type) {
+	case *tree.AndExpr:
+		left, err := b.buildDistance(e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.buildDistance(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case left == nil:
+			return right, nil
+		case right == nil:
+			return left, nil
+		}
+		return &tree.AndExpr{Left: left, Right: right}, nil
+	case *tree.ParenExpr:
+		ext, err := b.buildDistance(e.E)
+		if err != nil || ext == nil {
+			return nil, err
+		}
+		return &tree.ParenExpr{E: ext}, nil
+	case *tree.LtExpr:
+		return b.buildDistanceRange(n, e.Left, e.Right)
+	case *tree.LeExpr:
+		return b.buildDistanceRange(n, e.Left, e.Right)
+	case *tree.GtExpr:
+		return b.buildDistanceRange(n, e.Right, e.Left)
+	case *tree.GeExpr:
+		return b.buildDistanceRange(n, e.Right, e.Left)
+	}
+	return n, nil
+}
+
+func (b *build) buildDistanceRange(n, left, right tree.ExprStatement) (tree.ExprStatement, error) {
+	f, ok := left.(*tree.FuncExpr)
+	if !ok || strings.ToLower(f.Name) != "distance" {
+		return n, nil
+	}
+	if len(f.Es) != 1 {
+		return nil, fmt.Errorf("illegal arguments in call to '%s'", f)
+	}
+	if _, ok := f.Es[0].(tree.ColunmNameList); !ok {
+		return nil, fmt.Errorf("'%s' is not vector column", f.Es[0])
+	}
+	r, err := b.buildExprFloatConstant(right)
+	if err != nil {
+		return nil, err
+	}
+	if r <= 0 {
+		return nil, fmt.Errorf("illegal distance '%s'", right)
+	}
+	if b.rd == nil || r < *b.rd {
+		b.rd = &r
+	}
+	return nil, nil
+}
+
+func (b *build) buildExprFloatConstant(n tree.ExprStatement) (float32, error) {
+	if e, ok := n.(*tree.Value); ok {
+		switch e.E.(type) {
+		case *value.Int:
+			return float32(value.MustBeInt(e.E)), nil
+		case *value.Float:
+			return float32(value.MustBeFloat(e.E)), nil
+		}
+	}
+	return 0, fmt.Errorf("'%s' is not float", n)
+}
@@ -0,0 +1,55 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+)
+
+func TestRange(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	// the squared distances of the uids 1 to 6 are 1, 4, 9, 16, 25, 36
+	queryTests(t, e, []struct{ sql, want string }{
+		{
+			"select uid, name from user where distance(pic) < 10",
+			"SELECT uid, name FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, name FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select uid from user where distance(pic) < 20 and age > 10",
+			"SELECT uid FROM (WITH [34359738368, 51539607552, 68719476736] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select uid from user where distance(pic) < 10 and age > 10 top 1",
+			"SELECT uid FROM (WITH [34359738368] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select uid from user where age > 10 and distance(pic) < 10 ftop 5",
+			"SELECT uid FROM (WITH [34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids AND uid IN [2, 3] ORDER BY no)",
+		},
+		{
+			"select uid from user where distance(pic) < 20 and (distance(pic) < 10)",
+			"SELECT uid FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{"select uid from user where distance(pic) < 20 or age > 10", ""},
+		{"select uid from user where distance(pic) > 10", ""},
+		{"select uid from user where distance(pic) < 'a'", ""},
+		{"select distance(pic) from user", ""},
+		{"select uid from user order by distance(pic)", ""},
+	})
+	// the range search without top is limited by the configuration
+	for _, test := range []struct {
+		limit int
+		want  int64
+	}{
+		{0, op.DefaultRangeLimit},
+		{2, 2},
+	} {
+		if _, _, err := e.query("select uid from user where distance(pic) < 10", &op.Config{Dim: 2, RangeLimit: test.limit}); err != nil {
+			t.Fatal(err)
+		}
+		if len(e.vs.ns) != 1 || e.vs.ns[0] != test.want {
+			t.Errorf("range limit %v searches %v candidates, want %v", test.limit, e.vs.ns, test.want)
+		}
+	}
+}
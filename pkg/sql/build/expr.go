@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/deepfabric/vectorsql/pkg/sql/tree"
 	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
 	"github.com/deepfabric/vectorsql/pkg/vm/extend"
 	"github.com/deepfabric/vectorsql/pkg/vm/extend/overload"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
 	"github.com/deepfabric/vectorsql/pkg/vm/value"
 )
 
@@ -73,6 +75,9 @@ func (b *build) buildExpr(n tree.ExprStatement, id string) (extend.Extend, error
 		}
 		return &extend.BinaryExtend{overload.Mult, left, right}, nil
 	case *tree.PlusExpr:
+		if x, ok := e.Right.(*tree.IntervalExpr); ok {
+			return b.buildExprInterval(e.Left, x, 1, id)
+		}
 		left, err := b.buildExpr(e.Left, id)
 		if err != nil {
 			return nil, err
@@ -83,6 +88,9 @@ func (b *build) buildExpr(n tree.ExprStatement, id string) (extend.Extend, error
 		}
 		return &extend.BinaryExtend{overload.Plus, left, right}, nil
 	case *tree.MinusExpr:
+		if x, ok := e.Right.(*tree.IntervalExpr); ok {
+			return b.buildExprInterval(e.Left, x, -1, id)
+		}
 		left, err := b.buildExpr(e.Left, id)
 		if err != nil {
 			return nil, err
@@ -160,6 +168,10 @@ func (b *build) buildExpr(n tree.ExprStatement, id string) (extend.Extend, error
 		return &extend.BinaryExtend{overload.GE, left, right}, nil
 	case *tree.Subquery:
 		return nil, errors.New("subquery not support now")
+	case *tree.InExpr:
+		return b.buildExprIn(n, overload.In, e.Left, e.Right, id)
+	case *tree.NotInExpr:
+		return b.buildExprIn(n, overload.NotIn, e.Left, e.Right, id)
 	case *tree.BetweenExpr:
 		ext, err := b.buildExpr(e.E, id)
 		if err != nil {
@@ -217,15 +229,19 @@ func (b *build) buildExpr(n tree.ExprStatement, id string) (extend.Extend, error
 		if err != nil {
 			return nil, err
 		}
-		return &extend.BinaryExtend{overload.EQ, ext, value.ConstNull}, nil
+		return &extend.UnaryExtend{overload.IsNull, ext}, nil
 	case *tree.IsNotNullExpr:
 		ext, err := b.buildExpr(e.E, id)
 		if err != nil {
 			return nil, err
 		}
-		return &extend.BinaryExtend{overload.NE, ext, value.ConstNull}, nil
+		return &extend.UnaryExtend{overload.IsNotNull, ext}, nil
 	case *tree.FuncExpr:
 		return b.buildExprFunc(e, id)
+	case *tree.CaseExpr:
+		return b.buildExprCase(e, id)
+	case *tree.IntervalExpr:
+		return nil, fmt.Errorf("'%s' must be added to or subtracted from a datetime", n)
 	case *tree.ParenExpr:
 		ext, err := b.buildExpr(e.E, id)
 		if err != nil {
@@ -249,9 +265,18 @@ func (b *build) buildExpr(n tree.ExprStatement, id string) (extend.Extend, error
 
 func (b *build) buildExprFunc(n *tree.FuncExpr, id string) (extend.Extend, error) {
 	n.Name = strings.ToLower(n.Name)
+	if n.Name == "distance" {
+		return nil, fmt.Errorf("'%s' must be used as 'distance(column) < radius' in where clause", n)
+	}
 	if _, ok := AggFuncs[n.Name]; ok {
 		return nil, fmt.Errorf("unexpected aggregate expression '%s' in where clause", n)
 	}
+	if n.Name == "now" {
+		if len(n.Es) > 0 {
+			return nil, fmt.Errorf("too many arguments in call to '%s'", n.Name)
+		}
+		return value.NewTimestamp(time.Now()), nil
+	}
 	op, ok := ExtendFuncs[n.Name]
 	if !ok {
 		return nil, fmt.Errorf("unimplemented functions: %s", n.Name)
@@ -265,10 +290,10 @@ func (b *build) buildExprFunc(n *tree.FuncExpr, id string) (extend.Extend, error
 		if err != nil {
 			return nil, err
 		}
-		return &extend.UnaryExtend{
+		return reduce(&extend.UnaryExtend{
 			E:  e,
 			Op: op,
-		}, nil
+		}), nil
 	case overload.Binary:
 		if len(n.Es) < 2 {
 			return nil, fmt.Errorf("not enough arguments in call to '%s'", n.Name)
@@ -290,6 +315,9 @@ func (b *build) buildExprFunc(n *tree.FuncExpr, id string) (extend.Extend, error
 	default: // multi
 		var args []extend.Extend
 
+		if !overload.MultiArgs(op, len(n.Es)) {
+			return nil, fmt.Errorf("wrong number of arguments in call to '%s'", n.Name)
+		}
 		for i := range n.Es {
 			arg, err := b.buildExpr(n.Es[i], id)
 			if err != nil {
@@ -299,11 +327,139 @@ func (b *build) buildExprFunc(n *tree.FuncExpr, id string) (extend.Extend, error
 		}
 		return &extend.MultiExtend{
 			Op:   op,
-			Args: args,
+			Args: castArgs(op, args),
 		}, nil
 	}
 }
 
+// buildExprCase builds case as multiIf, the conditions of simple case are
+// the equality to E, the missing else is null.
+func (b *build) buildExprCase(n *tree.CaseExpr, id string) (extend.Extend, error) {
+	var e extend.Extend
+	var args []extend.Extend
+
+	if n.E != nil {
+		var err error
+		if e, err = b.buildExpr(n.E, id); err != nil {
+			return nil, err
+		}
+	}
+	for _, w := range n.Whens {
+		cond, err := b.buildExpr(w.Cond, id)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			cond = &extend.BinaryExtend{Op: overload.EQ, Left: e, Right: cond}
+		}
+		val, err := b.buildExpr(w.Val, id)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, cond, val)
+	}
+	if n.Else == nil {
+		args = append(args, value.ConstNull)
+	} else {
+		val, err := b.buildExpr(n.Else, id)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, val)
+	}
+	return &extend.MultiExtend{Op: overload.MultiIf, Args: castArgs(overload.MultiIf, args)}, nil
+}
+
+// castArgs casts the untyped constants of the arguments of op to the
+// type of the other arguments, since the arguments must be of the same
+// type, such as 18 of greatest(age, 18).
+func castArgs(op int, args []extend.Extend) []extend.Extend {
+	typ := uint32(types.T_null)
+	for i, arg := range args {
+		if _, ok := arg.(value.Value); !ok && !overload.IsCondition(op, i, len(args)) {
+			if typ = arg.ReturnType(); typ != types.T_null {
+				break
+			}
+		}
+	}
+	for i, arg := range args {
+		if v, ok := arg.(value.Value); ok && !overload.IsCondition(op, i, len(args)) {
+			args[i] = castValue(typ, v)
+		}
+	}
+	return args
+}
+
+// castValue returns the untyped constant v as the value of type typ, v
+// itself is returned if it cannot be cast.
+func castValue(typ uint32, v value.Value) value.Value {
+	switch x := v.(type) {
+	case *value.Int:
+		switch n := int64(*x); {
+		case typ == types.T_int8 && int64(int8(n)) == n:
+			return value.NewInt8(int8(n))
+		case typ == types.T_int16 && int64(int16(n)) == n:
+			return value.NewInt16(int16(n))
+		case typ == types.T_int32 && int64(int32(n)) == n:
+			return value.NewInt32(int32(n))
+		case typ == types.T_int64:
+			return value.NewInt64(n)
+		case typ == types.T_uint8 && int64(uint8(n)) == n:
+			return value.NewUint8(uint8(n))
+		case typ == types.T_uint16 && int64(uint16(n)) == n:
+			return value.NewUint16(uint16(n))
+		case typ == types.T_uint32 && int64(uint32(n)) == n:
+			return value.NewUint32(uint32(n))
+		case typ == types.T_uint64 && n >= 0:
+			return value.NewUint64(uint64(n))
+		case typ == types.T_float:
+			return value.NewFloat(float64(n))
+		case typ == types.T_float32:
+			return value.NewFloat32(float32(n))
+		case typ == types.T_float64:
+			return value.NewFloat64(float64(n))
+		case typ == types.T_timestamp:
+			return value.NewTimestamp(time.Unix(n, 0))
+		}
+	case *value.Float:
+		switch typ {
+		case types.T_float32:
+			return value.NewFloat32(float32(*x))
+		case types.T_float64:
+			return value.NewFloat64(float64(*x))
+		}
+	case *value.String:
+		if typ == types.T_timestamp {
+			if t, err := value.ParseTimestamp(string(*x)); err == nil {
+				return t
+			}
+		}
+	}
+	return v
+}
+
+// buildExprIn builds the list of constants of in, the subqueries are
+// extracted by buildIn.
+func (b *build) buildExprIn(n tree.ExprStatement, op int, left, right tree.ExprStatement, id string) (extend.Extend, error) {
+	es, ok := right.(tree.ExprStatements)
+	if !ok {
+		return nil, fmt.Errorf("'%s' must be used in the conjunctions of where clause", n)
+	}
+	e, err := b.buildExpr(left, id)
+	if err != nil {
+		return nil, err
+	}
+	args := []extend.Extend{e}
+	for _, x := range es {
+		v, ok := x.(*tree.Value)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not a constant", x)
+		}
+		args = append(args, v.E)
+	}
+	return &extend.MultiExtend{Op: op, Args: args}, nil
+}
+
 func (b *build) buildExprColumn(ns tree.ColunmNameList) (string, error) {
 	var name string
 
@@ -328,6 +484,9 @@ func (b *build) buildExprColumn(ns tree.ColunmNameList) (string, error) {
 func (b *build) buildExprIntConstant(n tree.ExprStatement) (int64, error) {
 	switch e := n.(type) {
 	case *tree.Value:
+		if _, ok := e.E.(*value.Int); !ok {
+			return 0, fmt.Errorf("'%s' is not integer", n)
+		}
 		return value.MustBeInt(e.E), nil
 	case *tree.ModExpr:
 		x, err := b.buildExprIntConstant(e.Left)
@@ -380,10 +539,6 @@ func (b *build) buildExprIntConstant(n tree.ExprStatement) (int64, error) {
 	}
 }
 
-func reduce(e extend.Extend) extend.Extend {
-	return e
-}
-
 var ExtendFuncs map[string]int = map[string]int{
 	"abs":     overload.Abs,
 	"ceil":    overload.Ceil,
@@ -398,4 +553,27 @@ var ExtendFuncs map[string]int = map[string]int{
 	"cast":    overload.Typecast,
 	"like":    overload.Like,
 	"notlike": overload.NotLike,
+
+	"if":       overload.If,
+	"least":    overload.Least,
+	"multiif":  overload.MultiIf,
+	"coalesce": overload.Coalesce,
+	"greatest": overload.Greatest,
+
+	"todate":       overload.ToDate,
+	"toyear":       overload.ToYear,
+	"tomonth":      overload.ToMonth,
+	"todayofmonth": overload.ToDayOfMonth,
+	"todayofweek":  overload.ToDayOfWeek,
+	"tohour":       overload.ToHour,
+	"tominute":     overload.ToMinute,
+	"tosecond":     overload.ToSecond,
+	"datetrunc":    overload.DateTrunc,
+	"date_trunc":   overload.DateTrunc,
+
+	"norm":           overload.Norm,
+	"normalize":      overload.Normalize,
+	"l2distance":     overload.L2Distance,
+	"innerproduct":   overload.InnerProduct,
+	"cosinedistance": overload.CosineDistance,
 }
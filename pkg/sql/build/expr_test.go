@@ -0,0 +1,222 @@
+package build
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/dynamic"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
+	"github.com/pilosa/pilosa/roaring"
+)
+
+var exprAttributes = attributes{
+	"a": types.T_int64,
+	"b": types.T_int64,
+	"u": types.T_uint8,
+	"s": types.T_string,
+}
+
+// evalExpr builds the select expression q of relation t and evaluates
+// it over the rows of mp, the rows are shown as strings.
+func evalExpr(q string, mp map[string]value.Values) ([]string, error) {
+	n, err := parser.Parse("select " + q + " from t")
+	if err != nil {
+		return nil, err
+	}
+	e, err := New("", exprAttributes, nil).buildExpr(n.Relation.(*tree.SelectClause).Sel[0].E, "t")
+	if err != nil {
+		return nil, err
+	}
+	vs, _, err := e.Eval(mp)
+	if err != nil {
+		return nil, err
+	}
+	var np *roaring.Bitmap
+	var rs []string
+
+	switch x := vs.(type) {
+	case *static.Ints:
+		for _, v := range x.Vs {
+			rs = append(rs, fmt.Sprint(v))
+		}
+		np = x.Np
+	case *static.Int64s:
+		for _, v := range x.Vs {
+			rs = append(rs, fmt.Sprint(v))
+		}
+		np = x.Np
+	case *static.Uint8s:
+		for _, v := range x.Vs {
+			rs = append(rs, fmt.Sprint(v))
+		}
+		np = x.Np
+	case *static.Bools:
+		for _, v := range x.Vs {
+			rs = append(rs, fmt.Sprint(v))
+		}
+		np = x.Np
+	case *dynamic.Strings:
+		rs = append(rs, x.Vs...)
+		np = x.Np
+	default:
+		return nil, fmt.Errorf("unexpected values %T", vs)
+	}
+	for i := range rs {
+		if np != nil && np.Contains(uint64(i)) {
+			rs[i] = "null"
+		}
+	}
+	return rs, nil
+}
+
+func TestMultiFunctions(t *testing.T) {
+	mp := map[string]value.Values{
+		"a": static.NewInt64s([]int64{1, 2, 3, 4}, nil, nil),
+		"b": static.NewInt64s([]int64{4, 3, 2, 1}, nil, nil),
+		"u": static.NewUint8s([]uint8{10, 20, 30, 40}, nil, nil),
+		"s": dynamic.NewStrings([]string{"w", "x", "y", "z"}, nil, nil),
+	}
+	tests := []struct {
+		q    string
+		want []string // nil if it fails
+	}{
+		{"coalesce(a, b)", []string{"1", "2", "3", "4"}},
+		{"coalesce(null, a)", []string{"1", "2", "3", "4"}},
+		{"coalesce(a, 0)", []string{"1", "2", "3", "4"}},
+		{"greatest(a, b)", []string{"4", "3", "3", "4"}},
+		{"least(a, b, 2)", []string{"1", "2", "2", "1"}},
+		{"greatest(u, 18)", []string{"18", "20", "30", "40"}},
+		{"least(a, null)", []string{"null", "null", "null", "null"}},
+		{"concat(s, 'q', s)", []string{"wqw", "xqx", "yqy", "zqz"}},
+		{"if(a > 1, a, b)", []string{"4", "2", "3", "4"}},
+		{"if(a > 1, s, null)", []string{"null", "x", "y", "z"}},
+		{"multiIf(a = 1, 10, b = 2, 20, 0)", []string{"10", "0", "20", "0"}},
+		{"case when a > 2 then 'big' when a > 1 then 'mid' else 'small' end", []string{"small", "mid", "big", "big"}},
+		{"case a when 1 then 'one' when 3 then 'three' end", []string{"one", "null", "three", "null"}},
+		{"case when null then 1 else 2 end", []string{"2", "2", "2", "2"}},
+		{"case when a > 2 then true else false end", []string{"false", "false", "true", "true"}},
+		{"greatest(a)", nil},
+		{"multiIf(a = 1, 2)", nil},
+		{"if(a > 1, a)", nil},
+		{"concat(a, s)", nil},
+		{"case when s then 1 end", nil},
+		{"case when a = 1 then 1 else 'x' end", nil},
+		{"greatest(u, 300)", nil},
+	}
+	for _, test := range tests {
+		rs, err := evalExpr(test.q, mp)
+		switch {
+		case test.want == nil && err == nil:
+			t.Errorf("%s = %v, want error", test.q, rs)
+		case test.want != nil && err != nil:
+			t.Errorf("%s: %v", test.q, err)
+		case test.want != nil && !reflect.DeepEqual(rs, test.want):
+			t.Errorf("%s = %v, want %v", test.q, rs, test.want)
+		}
+	}
+}
+
+func TestMultiFunctionsOfNulls(t *testing.T) {
+	mp := map[string]value.Values{
+		"a": static.NewInt64s([]int64{1, 0, 3, 4}, roaring.NewBitmap(1), nil),
+		"b": static.NewInt64s([]int64{4, 3, 2, 0}, roaring.NewBitmap(3), nil),
+		"s": dynamic.NewStrings([]string{"w", "x", "", "z"}, roaring.NewBitmap(2), nil),
+	}
+	tests := []struct {
+		q    string
+		want []string
+	}{
+		{"coalesce(a, b)", []string{"1", "3", "3", "4"}},
+		{"coalesce(b, a)", []string{"4", "3", "2", "4"}},
+		{"greatest(a, b)", []string{"4", "null", "3", "null"}},
+		{"least(a, b)", []string{"1", "null", "2", "null"}},
+		{"concat(s, s)", []string{"ww", "xx", "null", "zz"}},
+		{"if(a > b, a, b)", []string{"4", "3", "3", "null"}},
+		{"case when a < b then s else concat(s, s) end", []string{"w", "xx", "null", "zz"}},
+	}
+	for _, test := range tests {
+		rs, err := evalExpr(test.q, mp)
+		switch {
+		case err != nil:
+			t.Errorf("%s: %v", test.q, err)
+		case !reflect.DeepEqual(rs, test.want):
+			t.Errorf("%s = %v, want %v", test.q, rs, test.want)
+		}
+	}
+}
+
+func TestNullLogic(t *testing.T) {
+	// rows cover every pair of true, false and null of a > 1 and b > 1
+	mp := map[string]value.Values{
+		"a": static.NewInt64s([]int64{2, 2, 2, 1, 1, 1, 0, 0, 0}, roaring.NewBitmap(6, 7, 8), nil),
+		"b": static.NewInt64s([]int64{2, 1, 0, 2, 1, 0, 2, 1, 0}, roaring.NewBitmap(2, 5, 8), nil),
+	}
+	tests := []struct {
+		q    string
+		want []string
+	}{
+		{"a > 1", []string{"true", "true", "true", "false", "false", "false", "null", "null", "null"}},
+		{"a > 1 and b > 1", []string{"true", "false", "null", "false", "false", "false", "null", "false", "null"}},
+		{"a > 1 or b > 1", []string{"true", "true", "true", "true", "false", "null", "true", "null", "null"}},
+		{"not a > 1", []string{"false", "false", "false", "true", "true", "true", "null", "null", "null"}},
+		{"a is null", []string{"false", "false", "false", "false", "false", "false", "true", "true", "true"}},
+		{"b is not null", []string{"true", "true", "false", "true", "true", "false", "true", "true", "false"}},
+		{"a is null or b > 1", []string{"true", "false", "null", "true", "false", "null", "true", "true", "true"}},
+		{"a = b", []string{"true", "false", "null", "false", "true", "null", "null", "null", "null"}},
+		{"a = null", []string{"null", "null", "null", "null", "null", "null", "null", "null", "null"}},
+		{"null <> b", []string{"null", "null", "null", "null", "null", "null", "null", "null", "null"}},
+		{"not a is null", []string{"true", "true", "true", "true", "true", "true", "false", "false", "false"}},
+		{"a = null or b > 1", []string{"true", "null", "null", "true", "null", "null", "true", "null", "null"}},
+		{"a + b", []string{"4", "3", "null", "3", "2", "null", "null", "null", "null"}},
+	}
+	for _, test := range tests {
+		rs, err := evalExpr(test.q, mp)
+		switch {
+		case err != nil:
+			t.Errorf("%s: %v", test.q, err)
+		case !reflect.DeepEqual(rs, test.want):
+			t.Errorf("%s = %v, want %v", test.q, rs, test.want)
+		}
+	}
+}
+
+func TestNullConditions(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql  string
+		want string // the first query sent to clickhouse, empty if none
+	}{
+		{"select uid from user where age is null", ""},
+		{"select uid from user where not age is null and age > 40", "SELECT uid FROM user_item WHERE uid IN [5, 6]"},
+		{"select uid from user where age is not null and city = 'sh'", "SELECT uid FROM user_item WHERE uid IN [2, 4, 6]"},
+		{
+			"select uid from user where age = null",
+			"WITH (SELECT groupBitmapState(uid) FROM user_item WHERE age = NULL) AS bm0 SELECT CAST(bm0 AS String) AS result",
+		},
+		{
+			"select uid from user where age <> null and city = 'sh'",
+			"WITH (SELECT groupBitmapState(uid) FROM user_item WHERE age <> NULL) AS bm0 SELECT CAST(bm0 AS String) AS result",
+		},
+		{
+			"select uid from user where name is null or age > 40",
+			"WITH (SELECT groupBitmapState(uid) FROM user_item WHERE name IS NULL) AS bm0, (SELECT groupBitmapState(uid) FROM user_item WHERE age > 40) AS bm1 SELECT CAST(bitmapOr(bm0, bm1) AS String) AS result",
+		},
+	}
+	for _, test := range tests {
+		qs, _, err := e.query(test.sql, nil)
+		switch {
+		case err != nil:
+			t.Errorf("%s: %v", test.sql, err)
+		case len(test.want) == 0 && len(qs) > 0:
+			t.Errorf("%s sends %v, want none", test.sql, qs)
+		case len(test.want) > 0 && (len(qs) == 0 || qs[0] != test.want):
+			t.Errorf("%s:\n got %v\nwant %s", test.sql, qs, test.want)
+		}
+	}
+}
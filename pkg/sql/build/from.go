@@ -21,7 +21,7 @@ func (b *build) buildFrom(n *tree.From) (string, error) {
 func (b *build) buildAliasedTable(n *tree.AliasedTable) (string, error) {
 	switch t := n.Tbl.(type) {
 	case *tree.Subquery:
-		return "", fmt.Errorf("'%s' unsupport now", n)
+		return b.buildDerivedTable(n, t)
 	case *tree.TableName:
 		return b.buildTableName(t)
 	default:
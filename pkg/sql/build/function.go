@@ -0,0 +1,169 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend/overload"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+)
+
+// buildFuncs checks the vector functions in n and renames them to
+// the names used by clickhouse.
+func (b *build) buildFuncs(n tree.ExprStatement, id string) error {
+	return walkFuncs(n, func(e *tree.FuncExpr) error {
+		return b.buildFunc(e, id, false)
+	})
+}
+
+// buildRank is similar to buildFuncs, the ranking functions of top are
+// allowed in n.
+func (b *build) buildRank(n tree.ExprStatement, id string) error {
+	return walkFuncs(n, func(e *tree.FuncExpr) error {
+		return b.buildFunc(e, id, true)
+	})
+}
+
+func (b *build) buildFunc(e *tree.FuncExpr, id string, isRank bool) error {
+	name := strings.ToLower(e.Name)
+	if name == "distance" {
+		return fmt.Errorf("'%s' must be used as 'distance(column) < radius' in where clause", e)
+	}
+	if _, ok := RankFuncs[name]; ok {
+		if !isRank {
+			return fmt.Errorf("'%s' must be used in the order by of top", e)
+		}
+		return b.buildRankFunc(e, id)
+	}
+	ckName, ok := VectorFuncs[name]
+	if !ok {
+		return nil
+	}
+	narg := 1
+	if overload.OperatorType(ExtendFuncs[name]) == overload.Binary {
+		narg = 2
+	}
+	if len(e.Es) < narg {
+		return fmt.Errorf("not enough arguments in call to '%s'", e.Name)
+	}
+	for i := 0; i < narg; i++ {
+		if err := b.buildVectorArg(e.Es[i], id); err != nil {
+			return err
+		}
+	}
+	e.Name = ckName
+	return nil
+}
+
+// walkFuncs calls fn for every function of n, the arguments of a function
+// are visited before the function.
+func walkFuncs(n tree.ExprStatement, fn func(*tree.FuncExpr) error) error {
+	switch e := n.(type) {
+	case *tree.NotExpr:
+		return walkFuncs(e.E, fn)
+	case *tree.UnaryMinusExpr:
+		return walkFuncs(e.E, fn)
+	case *tree.IsNullExpr:
+		return walkFuncs(e.E, fn)
+	case *tree.IsNotNullExpr:
+		return walkFuncs(e.E, fn)
+	case *tree.ParenExpr:
+		return walkFuncs(e.E, fn)
+	case *tree.OrExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.AndExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.DivExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.ModExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.MultExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.PlusExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.MinusExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.EqExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.NeExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.LtExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.LeExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.GtExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.GeExpr:
+		return walkFuncsList(fn, e.Left, e.Right)
+	case *tree.BetweenExpr:
+		return walkFuncsList(fn, e.E, e.From, e.To)
+	case *tree.NotBetweenExpr:
+		return walkFuncsList(fn, e.E, e.From, e.To)
+	case *tree.FuncExpr:
+		if err := walkFuncsList(fn, e.Es...); err != nil {
+			return err
+		}
+		return fn(e)
+	case *tree.CaseExpr:
+		if e.E != nil {
+			if err := walkFuncs(e.E, fn); err != nil {
+				return err
+			}
+		}
+		for _, w := range e.Whens {
+			if err := walkFuncsList(fn, w.Cond, w.Val); err != nil {
+				return err
+			}
+		}
+		if e.Else != nil {
+			return walkFuncs(e.Else, fn)
+		}
+	}
+	return nil
+}
+
+// buildVectorArg checks that n is a vector without renaming its columns.
+func (b *build) buildVectorArg(n tree.ExprStatement, id string) error {
+	switch e := n.(type) {
+	case *tree.Value:
+		if e.E.ResolvedType() == types.T_vector {
+			return nil
+		}
+	case *tree.ParenExpr:
+		return b.buildVectorArg(e.E, id)
+	case *tree.FuncExpr:
+		if e.Name == VectorFuncs["normalize"] {
+			return nil
+		}
+	case tree.ColunmNameList:
+		if len(e) == 1 && e[0].Index == nil {
+			typ, err := b.c.AttributeType(string(e[0].Path), id)
+			if err != nil {
+				return err
+			}
+			if typ == types.T_vector {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("'%s' is not a vector", n)
+}
+
+func walkFuncsList(fn func(*tree.FuncExpr) error, ns ...tree.ExprStatement) error {
+	for _, n := range ns {
+		if err := walkFuncs(n, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VectorFuncs maps the vector functions to the names used by clickhouse.
+var VectorFuncs map[string]string = map[string]string{
+	"norm":           "L2Norm",
+	"normalize":      "L2Normalize",
+	"l2distance":     "L2Distance",
+	"innerproduct":   "dotProduct",
+	"cosinedistance": "cosineDistance",
+}
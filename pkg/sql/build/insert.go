@@ -0,0 +1,139 @@
+package build
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/client"
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// BuildExec builds the statement of the exec interface, either the insert
+// or the create is returned. The rows of insert ... select are the result
+// of the query without vector search.
+func (b *build) BuildExec() (*op.Insert, *op.Create, error) {
+	n, err := parser.ParseStatement(b.sql)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch t := n.(type) {
+	case *tree.Insert:
+		o, err := b.buildInsert(t)
+		return o, nil, err
+	case *tree.CreateTable:
+		o, err := b.buildCreate(t)
+		return nil, o, err
+	}
+	return nil, nil, fmt.Errorf("'%s' cannot be executed", n)
+}
+
+func (b *build) buildInsert(n *tree.Insert) (*op.Insert, error) {
+	id, err := b.buildTableName(n.Table)
+	if err != nil {
+		return nil, err
+	}
+	r, err := b.stg.Relation(metadata.Ikey(id))
+	if err != nil {
+		return nil, err
+	}
+	cols, err := insertColumns(n.Columns, r.Metadata().Attrs)
+	if err != nil {
+		return nil, err
+	}
+	o := &op.Insert{Id: metadata.Ikey(id), R: r, Cols: cols}
+	if n.Select != nil {
+		sel, err := bind(b.c, n.Select, nil)
+		if err != nil {
+			return nil, err
+		}
+		if o.Q, err = b.buildStatement(sel); err != nil {
+			return nil, err
+		}
+		if o.Q.T != nil || len(o.Q.Us) > 0 {
+			return nil, fmt.Errorf("'%s' unsupport now", n.Select)
+		}
+		return o, nil
+	}
+	attrs := r.Metadata().Attrs
+	for _, row := range n.Values {
+		if len(row) != len(cols) {
+			return nil, fmt.Errorf("expected %v values, got %v", len(cols), len(row))
+		}
+		vs := make([]string, len(row))
+		for i, e := range row {
+			if vs[i], err = buildInsertValue(e); err != nil {
+				return nil, err
+			}
+			if vs[i] == client.NullField && !attrs[cols[i]].Nullable {
+				return nil, fmt.Errorf("attribute '%s' is not nullable", attrs[cols[i]].Name)
+			}
+		}
+		o.Rows = append(o.Rows, vs)
+	}
+	return o, nil
+}
+
+// insertColumns returns the attributes of columns, all attributes are
+// given if ns is empty. uid, xid and pic cannot be omitted.
+func insertColumns(ns tree.NameList, attrs []metadata.Attribute) ([]int, error) {
+	var cols []int
+
+	if len(ns) == 0 {
+		for i := range attrs {
+			cols = append(cols, i)
+		}
+		return cols, nil
+	}
+	mp := make(map[string]int)
+	for i, attr := range attrs {
+		mp[attr.Name] = i
+	}
+	used := make(map[int]struct{})
+	for _, n := range ns {
+		i, ok := mp[string(n)]
+		if !ok {
+			return nil, fmt.Errorf("attribute '%s' not exist", n)
+		}
+		if _, ok := used[i]; ok {
+			return nil, fmt.Errorf("attribute '%s' specified more than once", n)
+		}
+		used[i] = struct{}{}
+		cols = append(cols, i)
+	}
+	for i := 0; i < 3 && i < len(attrs); i++ {
+		if _, ok := used[i]; !ok {
+			return nil, fmt.Errorf("attribute '%s' cannot be omitted", attrs[i].Name)
+		}
+	}
+	return cols, nil
+}
+
+// buildInsertValue returns the constant e in the text form of the
+// insert interface, NULL is given as client.NullField.
+func buildInsertValue(e tree.ExprStatement) (string, error) {
+	switch n := e.(type) {
+	case *tree.Value:
+		switch v := n.E.(type) {
+		case *value.String:
+			return string(*v), nil
+		default:
+			if v == value.ConstNull {
+				return client.NullField, nil
+			}
+			return v.String(), nil
+		}
+	case *tree.ParenExpr:
+		return buildInsertValue(n.E)
+	case *tree.UnaryMinusExpr:
+		if v, ok := n.E.(*tree.Value); ok {
+			switch v.E.(type) {
+			case *value.Int, *value.Float:
+				return "-" + v.E.String(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("'%s' is not a constant", e)
+}
@@ -0,0 +1,92 @@
+package build
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+)
+
+func TestInsert(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql  string
+		want [][]string // nil if the insert fails
+	}{
+		{
+			"insert into user (uid, xid, pic, city) values (7, 7, 'g.png', 'gz'), (8, 8, 'h.png', 'sz')",
+			[][]string{{"7", "7", "g.png", "0", "gz", ""}, {"8", "8", "h.png", "0", "sz", ""}},
+		},
+		{
+			"insert into user (pic, xid, uid, age) values ('g.png', 7, 7, (20))",
+			[][]string{{"7", "7", "g.png", "20", "", ""}},
+		},
+		{
+			"insert into user values (7, 7, 'g.png', 20, 'gz', 'x')",
+			[][]string{{"7", "7", "g.png", "20", "gz", "x"}},
+		},
+		{"insert into user (uid, pic) values (7, 'g.png')", nil},
+		{"insert into user (uid, xid, pic, sex) values (7, 7, 'g.png', 1)", nil},
+		{"insert into user (uid, xid, pic, uid) values (7, 7, 'g.png', 7)", nil},
+		{"insert into user (uid, xid, pic) values (7, 7)", nil},
+		{"insert into user (uid, xid, pic, age) values (7, 7, 'g.png', age + 1)", nil},
+		{"insert into user (uid, xid, pic, age) values (7, 7, 'g.png', null)", nil},
+		{"insert into nosuch (uid, xid, pic) values (7, 7, 'g.png')", nil},
+	}
+	for _, test := range tests {
+		o, _, err := New(test.sql, e.ctx, e.stg).BuildExec()
+		if err == nil {
+			var rs [][]string
+
+			if rs, err = o.Tuples(logger.New(ioutil.Discard, ""), &op.Config{Dim: 2}, e.vs, e.cli); err == nil {
+				if test.want == nil {
+					t.Errorf("%s = %v, want error", test.sql, rs)
+				} else if !reflect.DeepEqual(rs, test.want) {
+					t.Errorf("%s = %v, want %v", test.sql, rs, test.want)
+				}
+				continue
+			}
+		}
+		if test.want != nil {
+			t.Errorf("%s: %v", test.sql, err)
+		}
+	}
+}
+
+func TestInsertSelect(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql  string
+		want string // empty if the insert fails
+	}{
+		{
+			"insert into user (uid, xid, pic) select uid, xid, pic from user where age > 40",
+			"SELECT uid, xid, pic FROM user_item WHERE uid IN [5, 6]",
+		},
+		{
+			"insert into user (uid, xid, pic, name) select uid + 6, xid, pic, name from user where city = 'sh' fetch first 2 rows only",
+			"SELECT uid + 6, xid, pic, name FROM user_item WHERE uid IN [2, 4, 6] LIMIT 2",
+		},
+		{"insert into user (uid, xid, pic) select uid, xid, pic from user top 2", ""},
+		{"insert into user (uid, xid, pic) select uid, xid, pic from user union select uid, xid, pic from user top 2", ""},
+	}
+	for _, test := range tests {
+		e.cli.qs = nil
+		o, _, err := New(test.sql, e.ctx, e.stg).BuildExec()
+		if err == nil {
+			_, err = o.Tuples(logger.New(ioutil.Discard, ""), &op.Config{Dim: 2}, e.vs, e.cli)
+		}
+		switch {
+		case len(test.want) == 0 && err == nil:
+			t.Errorf("%s = %v, want error", test.sql, e.cli.qs)
+		case len(test.want) > 0 && err != nil:
+			t.Errorf("%s: %v", test.sql, err)
+		case len(test.want) > 0 && (len(e.cli.qs) == 0 || e.cli.qs[len(e.cli.qs)-1] != test.want):
+			t.Errorf("%s = %v, want %s", test.sql, e.cli.qs, test.want)
+		}
+	}
+}
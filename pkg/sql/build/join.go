@@ -0,0 +1,101 @@
+package build
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter/ck"
+	"github.com/deepfabric/vectorsql/pkg/vm/opt"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// buildJoin builds the inner join on uid, the filters of both sides are
+// evaluated as bitmaps and intersected, the vectors of the left side are
+// searched.
+func (b *build) buildJoin(n *tree.JoinClause) (string, extend.Extend, *tree.SelectClause, error) {
+	if n.Type != tree.InnerOp {
+		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n.Type)
+	}
+	if c, ok := n.Cond.(*tree.OnJoinCond); !ok || !isJoinOnUid(c.E) {
+		return "", nil, nil, fmt.Errorf("'%s' unsupport now, only join on uid", n)
+	}
+	left, ok := n.Left.(*tree.SelectClause)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n.Left)
+	}
+	right, ok := n.Right.(*tree.SelectClause)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n.Right)
+	}
+	lid, le, lsc, err := b.buildSelect(left)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if err := b.buildJoinFilter(lid, le); err != nil {
+		return "", nil, nil, err
+	}
+	rid, re, rsc, err := b.buildSelect(right)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if err := b.buildJoinFilter(rid, re); err != nil {
+		return "", nil, nil, err
+	}
+	return lid, nil, &tree.SelectClause{
+		Distinct: lsc.Distinct,
+		Sel:      append(lsc.Sel, rsc.Sel...),
+		From: &tree.From{
+			Tables: tree.TableStatements{&tree.JoinTable{
+				Type:  tree.InnerOp,
+				Using: tree.NameList{"uid"},
+				Left:  lsc.From.Tables[0],
+				Right: rsc.From.Tables[0],
+			}},
+		},
+	}, nil
+}
+
+// buildJoinFilter adds the filters of one side of join, a side without
+// where clause is limited to the uids of its table.
+func (b *build) buildJoinFilter(id string, e extend.Extend) error {
+	if e == nil {
+		b.fs = append(b.fs, b.relationFilter(id))
+		return nil
+	}
+	c, i, err := opt.New(b.c, b.stg).Optimize(e, id)
+	if err != nil {
+		return err
+	}
+	if c != nil {
+		b.fs = append(b.fs, c)
+	}
+	if i != nil {
+		b.fs = append(b.fs, i)
+	}
+	return nil
+}
+
+// relationFilter returns the filter of all uids of relation id.
+func (b *build) relationFilter(id string) filter.Filter {
+	bs := []dialect.Bitmap{{Name: "bm0", Table: metadata.Ikey(id), E: value.NewBool(true)}}
+	return ck.New(b.c.Client(), dialect.BitmapQuery(bs, "bm0"))
+}
+
+func isJoinOnUid(n tree.ExprStatement) bool {
+	switch e := n.(type) {
+	case *tree.ParenExpr:
+		return isJoinOnUid(e.E)
+	case *tree.EqExpr:
+		return isUid(e.Left) && isUid(e.Right)
+	}
+	return false
+}
+
+func isUid(n tree.ExprStatement) bool {
+	ns, ok := n.(tree.ColunmNameList)
+	return ok && len(ns) > 0 && ns[len(ns)-1].Path == "uid" && ns[len(ns)-1].Index == nil
+}
@@ -0,0 +1,31 @@
+package build
+
+import "testing"
+
+func TestJoin(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	// the uids 2 and 5 have events after 150
+	queryTests(t, e, []struct{ sql, want string }{
+		{
+			"select name from user where city = 'sh' join select uid from user where ts > 150 on user.uid = user.uid",
+			"SELECT name, uid FROM user_item INNER JOIN user_item USING (uid) WHERE uid IN [2]",
+		},
+		{
+			"select name from user where age > 30 join select uid from user where ts > 150 on uid = uid top 1",
+			"SELECT name, uid FROM (WITH [85899345920] AS xids SELECT indexOf(xids, xid) AS no, name, uid FROM user_item INNER JOIN user_item USING (uid) WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select name from user where city = 'bj' join select uid from user where ts > 150 on (uid = uid) top 5",
+			"SELECT name, uid FROM (WITH [85899345920] AS xids SELECT indexOf(xids, xid) AS no, name, uid FROM user_item INNER JOIN user_item USING (uid) WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select name from user where age > 40 join select uid from user on uid = uid",
+			"SELECT name, uid FROM user_item INNER JOIN user_item USING (uid) WHERE uid IN [5, 6]",
+		},
+		{"select name from user where age > 40 full join select uid from user on uid = uid", ""},
+		{"select name from user where age > 40 cross join select uid from user", ""},
+		{"select name from user join select uid from user on age = age", ""},
+		{"select name from user join select uid from user on uid = uid and age = age", ""},
+	})
+}
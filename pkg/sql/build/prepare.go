@@ -0,0 +1,70 @@
+package build
+
+import (
+	"github.com/deepfabric/vectorsql/pkg/lru"
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+	"github.com/deepfabric/vectorsql/pkg/storage"
+	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/opt"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// Prepare parses sql whose placeholders are bound by the executions.
+func Prepare(sql string, c context.Context, stg storage.Storage) (*Stmt, error) {
+	n, err := parser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	return &Stmt{
+		n:   n,
+		c:   c,
+		sql: sql,
+		stg: stg,
+		fs:  lru.New(MaxFilters),
+	}, nil
+}
+
+// Execute builds the statement with the arguments of placeholders.
+func (s *Stmt) Execute(args []value.Value) (*op.OP, error) {
+	n, err := bind(s.c, s.n, args)
+	if err != nil {
+		return nil, err
+	}
+	b := New(s.sql, s.c, s.stg)
+	b.stmt = s
+	return b.buildStatement(n)
+}
+
+// optimize returns the filters of condition e on relation id, the filters
+// of a prepared statement are cached for the same condition.
+func (b *build) optimize(e extend.Extend, id string) (filter.Filter, filter.Filter, error) {
+	if b.stmt == nil {
+		return opt.New(b.c, b.stg).Optimize(e, id)
+	}
+	return b.stmt.optimize(e, id)
+}
+
+// optimize keys the filters by the sql of condition e, whose constants
+// are quoted, so different conditions never share the filters.
+func (s *Stmt) optimize(e extend.Extend, id string) (filter.Filter, filter.Filter, error) {
+	k := dialect.Ident(id) + ": " + dialect.Extend(e)
+	s.Lock()
+	v, ok := s.fs.Get(k)
+	s.Unlock()
+	if ok {
+		f := v.(filters)
+		return f.cf, f.ef, nil
+	}
+	c, i, err := opt.New(s.c, s.stg).Optimize(e, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.Lock()
+	s.fs.Add(k, filters{c, i})
+	s.Unlock()
+	return c, i, nil
+}
@@ -0,0 +1,51 @@
+package build
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+func TestPrepare(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	stmt, err := Prepare("select uid from user where name = ? and city = ?", e.ctx, e.stg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the conditions of both executions are name = 'a' and city = 'bj'
+	// and city = 'sh' if the quotes of strings are not escaped
+	tests := []struct {
+		args []value.Value
+		want []string
+	}{
+		{
+			[]value.Value{value.NewString("a' and city = 'bj"), value.NewString("sh")},
+			[]string{
+				`WITH (SELECT groupBitmapState(uid) FROM user_item WHERE name = 'a\' and city = \'bj') AS bm0 SELECT CAST(bm0 AS String) AS result`,
+				"SELECT uid FROM user_item WHERE uid IN [2, 4, 6]",
+			},
+		},
+		{
+			[]value.Value{value.NewString("a"), value.NewString("bj' and city = 'sh")},
+			[]string{"WITH (SELECT groupBitmapState(uid) FROM user_item WHERE name = 'a') AS bm0 SELECT CAST(bm0 AS String) AS result"},
+		},
+	}
+	for _, test := range tests {
+		e.cli.qs = nil
+		o, err := stmt.Execute(test.args)
+		if err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+		if _, err := o.Result(logger.New(ioutil.Discard, ""), &op.Config{Dim: 2}, e.vs, e.cli, e.vec); err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+		if !reflect.DeepEqual(e.cli.qs, test.want) {
+			t.Errorf("%v = %v, want %v", test.args, e.cli.qs, test.want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package build
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/thinkkv/pkg/engine/pb"
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/lru"
+	"github.com/deepfabric/vectorsql/pkg/storage"
+	"github.com/deepfabric/vectorsql/pkg/storage/cache"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/bv"
+	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/util/distance"
+	Roaring "github.com/pilosa/pilosa/roaring"
+)
+
+// recorder is a clickhouse which records the queries instead of
+// executing them, the bitmap queries return mp.
+type recorder struct {
+	qs []string
+	mp *roaring.Bitmap
+}
+
+func (r *recorder) Close() error { return nil }
+
+func (r *recorder) Query(sql string) ([][]string, error) {
+	r.qs = append(r.qs, sql)
+	return nil, nil
+}
+
+func (r *recorder) Exec(sql string, _ [][]interface{}) error {
+	r.qs = append(r.qs, sql)
+	return nil
+}
+
+func (r *recorder) Bitmap(sql string) (*roaring.Bitmap, error) {
+	r.qs = append(r.qs, sql)
+	return r.mp.Clone(), nil
+}
+
+// vectorServer is an exact vector server in memory, ns are the numbers
+// of candidates of the searches.
+type vectorServer struct {
+	l    metadata.Layout
+	ns   []int64
+	xids []int64
+	xbs  [][]float32
+}
+
+func (s *vectorServer) Add(xbs []float32, xids []int64) error {
+	for i, xid := range xids {
+		s.xids = append(s.xids, xid)
+		s.xbs = append(s.xbs, xbs[i*2:(i+1)*2])
+	}
+	return nil
+}
+
+func (s *vectorServer) Remove(_ []int64) error               { return nil }
+func (s *vectorServer) Replace(_ []float32, _ []int64) error { return nil }
+
+func (s *vectorServer) Fvectors(n int64, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	return s.search(n, nil, v)
+}
+
+func (s *vectorServer) Vectors(n int64, mp *roaring.Bitmap, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	return s.search(n, mp, v)
+}
+
+func (s *vectorServer) Exact(n int64, mp *roaring.Bitmap, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	return s.search(n, mp, v)
+}
+
+func (s *vectorServer) Rerank(n int64, vs []uint64, _ []float32, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	mp := roaring.NewBitmap()
+	for _, x := range vs {
+		mp.Add(s.l.Uid(int64(x)))
+	}
+	return s.search(n, mp, v)
+}
+
+func (s *vectorServer) WithLayout(l metadata.Layout) bv.BV {
+	s.l = l
+	return s
+}
+
+func (s *vectorServer) search(n int64, mp *roaring.Bitmap, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	var xs []uint32
+	var ys []uint64
+	var zs []float32
+
+	s.ns = append(s.ns, n)
+	for i, xid := range s.xids {
+		if uid := s.l.Uid(xid); mp == nil || mp.Contains(uid) {
+			xs = append(xs, uid)
+			ys = append(ys, uint64(xid))
+			zs = append(zs, distance.SquaredL2(v, s.xbs[i]))
+		}
+	}
+	sort.Sort(&byDistance{ys, zs})
+	if int64(len(ys)) > n {
+		ys, zs = ys[:n], zs[:n]
+	}
+	xs = xs[:0]
+	for _, y := range ys {
+		xs = append(xs, s.l.Uid(int64(y)))
+	}
+	return roaring.BitmapOf(xs...), ys, zs, nil
+}
+
+type byDistance struct {
+	xids []uint64
+	ds   []float32
+}
+
+func (x *byDistance) Len() int           { return len(x.ds) }
+func (x *byDistance) Less(i, j int) bool { return x.ds[i] < x.ds[j] }
+func (x *byDistance) Swap(i, j int) {
+	x.ds[i], x.ds[j] = x.ds[j], x.ds[i]
+	x.xids[i], x.xids[j] = x.xids[j], x.xids[i]
+}
+
+// env is a relation user with the indexed attributes age and city, and
+// the vectors (uid, 0) of the uids 1 to 6. Its event relation has the
+// indexed attribute ts. The query vector is vec, or the vectors of fvs
+// fused by reciprocal rank.
+type env struct {
+	dir string
+	vec []float32
+	fvs []op.Vector
+	cli *recorder
+	vs  *vectorServer
+	stg storage.Storage
+	ctx context.Context
+}
+
+func newEnv(t *testing.T) *env {
+	dir, err := ioutil.TempDir("", "build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	e := &env{
+		dir: dir,
+		vec: []float32{0, 0},
+		cli: &recorder{mp: roaring.BitmapOf(1, 2, 3, 4, 5, 6)},
+		vs:  &vectorServer{l: metadata.DefaultLayout},
+		stg: storage.New(db, lru.New(10), cache.New(1<<20)),
+	}
+	e.ctx = context.New(e.cli, e.stg)
+	iattrs := []metadata.Attribute{
+		{Index: true, Type: types.T_uint64, Name: "uid"},
+		{Type: types.T_uint64, Name: "xid"},
+		{Type: types.T_string, Name: "pic"},
+		{Index: true, Type: types.T_uint8, Name: "age"},
+		{Index: true, Type: types.T_string, Name: "city"},
+		{Type: types.T_string, Name: "name"},
+	}
+	eattrs := []metadata.Attribute{
+		{Index: true, Type: types.T_uint64, Name: "uid"},
+		{Index: true, Type: types.T_timestamp, Name: "ts"},
+	}
+	if err := e.stg.NewRelation(metadata.Ikey("user"), metadata.Metadata{Attrs: iattrs}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.stg.NewRelation(metadata.Ekey("user"), metadata.Metadata{IsE: true, Attrs: eattrs}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := e.stg.Relation(metadata.Ikey("user"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddTuples([]interface{}{
+		[]uint64{1, 2, 3, 4, 5, 6},
+		[]uint64{1 << 34, 2 << 34, 3 << 34, 4 << 34, 5 << 34, 6 << 34},
+		[]string{"a", "b", "c", "d", "e", "f"},
+		[]uint8{10, 20, 30, 40, 50, 60},
+		[]string{"bj", "sh", "bj", "sh", "bj", "sh"},
+		[]string{"u", "v", "w", "x", "y", "z"},
+	}, make([]*Roaring.Bitmap, len(iattrs))); err != nil {
+		t.Fatal(err)
+	}
+	if r, err = e.stg.Relation(metadata.Ekey("user")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddTuples([]interface{}{
+		[]uint64{1, 2, 2, 5},
+		[]int64{100, 200, 300, 400},
+	}, make([]*Roaring.Bitmap, len(eattrs))); err != nil {
+		t.Fatal(err)
+	}
+	for uid := int64(1); uid <= 6; uid++ {
+		if err := e.vs.Add([]float32{float32(uid), 0}, []int64{uid << 34}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return e
+}
+
+func (e *env) close() {
+	e.stg.Close() // the db is closed by the storage
+	os.RemoveAll(e.dir)
+}
+
+// query runs sql with the query vectors, and returns the queries sent to
+// clickhouse and the op of sql.
+func (e *env) query(sql string, cfg *op.Config) ([]string, *op.OP, error) {
+	e.cli.qs, e.vs.ns = nil, nil
+	o, err := New(sql, e.ctx, e.stg).Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg == nil {
+		cfg = &op.Config{Dim: 2, FtopLimit: 100}
+	}
+	o.Vs = e.fvs
+	_, err = o.Result(logger.New(ioutil.Discard, ""), cfg, e.vs, e.cli, e.vec)
+	return e.cli.qs, o, err
+}
+
+// queryTests runs the queries of tests, and checks the last query sent to
+// clickhouse, want is empty if the query fails.
+func queryTests(t *testing.T, e *env, tests []struct{ sql, want string }) {
+	for _, test := range tests {
+		qs, _, err := e.query(test.sql, nil)
+		switch {
+		case len(test.want) == 0 && err == nil:
+			t.Errorf("%s = %v, want error", test.sql, qs)
+		case len(test.want) > 0 && err != nil:
+			t.Errorf("%s: %v", test.sql, err)
+		case len(test.want) > 0 && len(qs) == 0:
+			t.Errorf("%s sends no query, want %s", test.sql, test.want)
+		case len(test.want) > 0 && !reflect.DeepEqual(qs[len(qs)-1], test.want):
+			t.Errorf("%s:\n got %s\nwant %s", test.sql, qs[len(qs)-1], test.want)
+		}
+	}
+}
+
+func TestQueryVectors(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql  string
+		vec  []float32
+		fvs  []op.Vector
+		want string // empty if the query fails
+	}{
+		{"select uid from user where age > 40", nil, nil, "SELECT uid FROM user_item WHERE uid IN [5, 6]"},
+		{"select uid from user order by age desc fetch first 2 rows only", nil, nil, "SELECT uid FROM user_item ORDER BY age DESC LIMIT 2"},
+		{"select uid from user top 2", nil, nil, ""},
+		{"select uid from user where distance(pic) < 10", nil, nil, ""},
+		{"select uid from user union select uid from user top 2", nil, nil, ""},
+		{"select uid from user union all select uid from user", nil, nil, "SELECT uid FROM user_item UNION ALL SELECT uid FROM user_item"},
+		{"select uid from user top 2", []float32{0, 0, 0}, nil, ""},
+		{
+			"select uid from user top 2",
+			[]float32{3, 0},
+			nil,
+			"SELECT uid FROM (WITH [51539607552, 34359738368] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		// the nearest of (6, 0) are 6, 5, 4 and the nearest of (0, 0)
+		// are 1, 2, 3, the negative weight drops 6 and 5
+		{
+			"select uid from user top 2",
+			[]float32{0, 0},
+			[]op.Vector{{W: 1, Xb: []float32{0, 0}}, {W: -2, Xb: []float32{6, 0}}},
+			"SELECT uid FROM (WITH [17179869184, 34359738368] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select uid from user top 2",
+			[]float32{0, 0},
+			[]op.Vector{{W: 1, Xb: []float32{0, 0}}, {W: 1, Xb: []float32{6, 0, 0}}},
+			"",
+		},
+	}
+	for _, test := range tests {
+		e.vec, e.fvs = test.vec, test.fvs
+		qs, _, err := e.query(test.sql, nil)
+		switch {
+		case len(test.want) == 0 && err == nil:
+			t.Errorf("%s with %v = %v, want error", test.sql, test.vec, qs)
+		case len(test.want) > 0 && err != nil:
+			t.Errorf("%s with %v: %v", test.sql, test.vec, err)
+		case len(test.want) > 0 && (len(qs) == 0 || qs[len(qs)-1] != test.want):
+			t.Errorf("%s with %v = %v, want %s", test.sql, test.vec, qs, test.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// buildRankFunc rewrites the ranking functions of top to clickhouse expressions:
+//
+//	similarity() -> arrayElement(scores, indexOf(xids, xid))
+//	recency(ts) -> divide(1, plus(1, dateDiff('day', ts, now())))
+func (b *build) buildRankFunc(e *tree.FuncExpr, id string) error {
+	switch strings.ToLower(e.Name) {
+	case "similarity":
+		if len(e.Es) > 0 {
+			return fmt.Errorf("too many arguments in call to '%s'", e)
+		}
+		b.sim = true
+		e.Name = "arrayElement"
+		e.Es = tree.ExprStatements{tree.ColunmNameList{{Path: "scores"}}, &tree.Index{}}
+	case "recency":
+		if len(e.Es) != 1 {
+			return fmt.Errorf("'%s' requires a datetime column", e)
+		}
+		ns, ok := e.Es[0].(tree.ColunmNameList)
+		if !ok || len(ns) != 1 || ns[0].Index != nil {
+			return fmt.Errorf("'%s' requires a datetime column", e)
+		}
+		typ, err := b.c.AttributeType(string(ns[0].Path), id)
+		if err != nil {
+			return err
+		}
+		if typ != types.T_timestamp {
+			return fmt.Errorf("'%s' requires a datetime column", e)
+		}
+		e.Name = "divide"
+		e.Es = tree.ExprStatements{
+			&tree.Value{E: value.NewInt(1)},
+			&tree.FuncExpr{
+				Name: "plus",
+				Es: tree.ExprStatements{
+					&tree.Value{E: value.NewInt(1)},
+					&tree.FuncExpr{
+						Name: "dateDiff",
+						Es:   tree.ExprStatements{&tree.Value{E: value.NewString("day")}, ns, &tree.FuncExpr{Name: "now"}},
+					},
+				},
+			},
+		}
+	}
+	return nil
+}
+
+// RankFuncs contains the functions which can only be used in the order by of top.
+var RankFuncs map[string]struct{} = map[string]struct{}{
+	"recency":    struct{}{},
+	"similarity": struct{}{},
+}
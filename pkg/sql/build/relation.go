@@ -12,7 +12,7 @@ func (b *build) buildRelation(n tree.RelationStatement) (string, extend.Extend,
 	case *tree.AliasedTable:
 		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n)
 	case *tree.JoinClause:
-		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n)
+		return b.buildJoin(t)
 	case *tree.UnionClause:
 		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n)
 	case *tree.SelectClause:
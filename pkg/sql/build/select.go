@@ -11,6 +11,42 @@ func (b *build) buildSelect(n *tree.SelectClause) (string, extend.Extend, *tree.
 	if err != nil {
 		return "", nil, nil, err
 	}
+	if n.Where != nil {
+		e, err := b.buildDistance(n.Where.E)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if e == nil {
+			n.Where = nil
+		} else {
+			n.Where.E = e
+		}
+	}
+	if n.Where != nil {
+		e, err := b.buildIn(n.Where.E, id)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if e == nil {
+			n.Where = nil
+		} else {
+			n.Where.E = e
+		}
+	}
+	buildFuncs := b.buildFuncs
+	if b.top || b.rd != nil {
+		buildFuncs = b.buildRank
+	}
+	for _, sel := range n.Sel {
+		if err := buildFuncs(sel.E, id); err != nil {
+			return "", nil, nil, err
+		}
+	}
+	if n.Having != nil {
+		if err := buildFuncs(n.Having.E, id); err != nil {
+			return "", nil, nil, err
+		}
+	}
 	e, err := b.buildWhere(n.Where, id)
 	if err != nil {
 		return "", nil, nil, err
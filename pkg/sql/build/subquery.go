@@ -0,0 +1,138 @@
+package build
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter/difference"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter/intersect"
+)
+
+// buildIn extracts the predicates 'uid [NOT] IN (subquery)' from the
+// conjunctions of the where clause, and returns the remaining expression.
+// The lists of constants are left to the filters.
+func (b *build) buildIn(n tree.ExprStatement, id string) (tree.ExprStatement, error) {
+	switch e := n.(type) {
+	case *tree.AndExpr:
+		left, err := b.buildIn(e.Left, id)
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.buildIn(e.Right, id)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case left == nil:
+			return right, nil
+		case right == nil:
+			return left, nil
+		}
+		return &tree.AndExpr{Left: left, Right: right}, nil
+	case *tree.ParenExpr:
+		ext, err := b.buildIn(e.E, id)
+		if err != nil || ext == nil {
+			return nil, err
+		}
+		return &tree.ParenExpr{E: ext}, nil
+	case *tree.InExpr:
+		if _, ok := e.Right.(*tree.Subquery); !ok {
+			return n, nil
+		}
+		f, err := b.buildInSubquery(n, e.Left, e.Right)
+		if err != nil {
+			return nil, err
+		}
+		b.fs = append(b.fs, f)
+		return nil, nil
+	case *tree.NotInExpr:
+		if _, ok := e.Right.(*tree.Subquery); !ok {
+			return n, nil
+		}
+		f, err := b.buildInSubquery(n, e.Left, e.Right)
+		if err != nil {
+			return nil, err
+		}
+		b.fs = append(b.fs, difference.New(b.relationFilter(id), f))
+		return nil, nil
+	}
+	return n, nil
+}
+
+// buildInSubquery returns the filter of the uids selected by right.
+func (b *build) buildInSubquery(n, left, right tree.ExprStatement) (filter.Filter, error) {
+	sub, ok := right.(*tree.Subquery)
+	if !ok {
+		return nil, fmt.Errorf("'%s' unsupport now", n)
+	}
+	if !isUid(left) {
+		return nil, fmt.Errorf("'%s' unsupport now, only uid in subquery", n)
+	}
+	id, sc, f, err := b.buildSubquery(sub)
+	if err != nil {
+		return nil, err
+	}
+	if len(sc.Sel) != 1 || !isUid(sc.Sel[0].E) {
+		return nil, fmt.Errorf("subquery '%s' must select uid only", sub)
+	}
+	if f == nil {
+		return b.relationFilter(id), nil
+	}
+	return f, nil
+}
+
+// buildDerivedTable replaces the subquery of n by its table, the filters
+// of subquery restrict the uids of the query. The subquery must select
+// the columns without renaming or computing them, since the query
+// refers to the columns of its table.
+func (b *build) buildDerivedTable(n *tree.AliasedTable, sub *tree.Subquery) (string, error) {
+	id, sc, f, err := b.buildSubquery(sub)
+	if err != nil {
+		return "", err
+	}
+	if sc.Distinct || sc.GroupBy != nil || sc.Having != nil || len(sc.From.Tables) != 1 {
+		return "", fmt.Errorf("'%s' unsupport now", n)
+	}
+	for _, e := range sc.Sel {
+		if _, ok := e.E.(tree.ColunmNameList); !ok || len(e.As) > 0 {
+			return "", fmt.Errorf("'%s' unsupport now, only columns can be selected by derived table", e)
+		}
+	}
+	t, ok := sc.From.Tables[0].(*tree.AliasedTable)
+	if !ok {
+		return "", fmt.Errorf("'%s' unsupport now", n)
+	}
+	n.Tbl = t.Tbl
+	if f != nil {
+		b.fs = append(b.fs, f)
+	}
+	return id, nil
+}
+
+// buildSubquery builds the subquery n by a new build, the returned filter
+// is nil if n has no where clause.
+func (b *build) buildSubquery(n *tree.Subquery) (string, *tree.SelectClause, filter.Filter, error) {
+	if n.Exists || n.Select.Order != nil || n.Select.Limit != nil {
+		return "", nil, nil, fmt.Errorf("'%s' unsupport now", n)
+	}
+	sb := New(b.sql, b.c, b.stg)
+	sb.stmt = b.stmt
+	id, o, err := sb.buildQuery(n.Select.Relation)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if sb.rd != nil {
+		return "", nil, nil, fmt.Errorf("distance range search of '%s' unsupport now", n)
+	}
+	sc := o.N.Relation.(*tree.SelectClause)
+	switch {
+	case o.Cf != nil && o.If != nil:
+		return id, sc, intersect.New([]filter.Filter{o.Cf, o.If}), nil
+	case o.Cf != nil:
+		return id, sc, o.Cf, nil
+	case o.If != nil:
+		return id, sc, o.If, nil
+	}
+	return id, sc, nil, nil
+}
@@ -0,0 +1,33 @@
+package build
+
+import "testing"
+
+func TestSubquery(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	queryTests(t, e, []struct{ sql, want string }{
+		{
+			"select uid from user where uid in (select uid from user where age > 40) and city = 'sh'",
+			"SELECT uid FROM user_item WHERE uid IN [6]",
+		},
+		{
+			"select uid from user where uid not in (select uid from user where age > 10) top 2",
+			"SELECT uid FROM (WITH [17179869184] AS xids SELECT indexOf(xids, xid) AS no, uid FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{
+			"select name from (select name, age from user where city = 'sh') where age > 30",
+			"SELECT name FROM user_item WHERE uid IN [4, 6]",
+		},
+		{
+			"select name from (select * from user where city = 'sh') where age > 30 top 1",
+			"SELECT name FROM (WITH [68719476736] AS xids SELECT indexOf(xids, xid) AS no, name FROM user_item WHERE xid IN xids ORDER BY no)",
+		},
+		{"select uid from user where uid in (select age from user)", ""},
+		{"select uid from user where age in (select uid from user)", ""},
+		{"select uid from user where uid in (select uid from user top 1)", ""},
+		{"select uid from user where uid in (select uid from user where distance(pic) < 1)", ""},
+		{"select a from (select age as a from user)", ""},
+		{"select a from (select age + 1 from user)", ""},
+		{"select age from (select distinct age from user)", ""},
+	})
+}
@@ -1,5 +1,128 @@
 package build
 
+import (
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/storage/index"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+)
+
+// buildAggregate returns the aggregate of n if the aggregations of n can be
+// answered by the index of relation id, otherwise nil is returned and n is
+// answered by clickhouse.
+func (b *build) buildAggregate(n *tree.Select, id string) (*op.Aggregate, error) {
+	sc, ok := n.Relation.(*tree.SelectClause)
+	if !ok || n.Order != nil || n.Limit != nil || b.rd != nil {
+		return nil, nil
+	}
+	if sc.Distinct || sc.Having != nil || len(sc.Sel) == 0 || len(sc.From.Tables) != 1 {
+		return nil, nil
+	}
+	if _, ok := sc.From.Tables[0].(*tree.AliasedTable); !ok {
+		return nil, nil
+	}
+	r, err := b.stg.Relation(metadata.Ikey(id))
+	if err != nil {
+		return nil, err
+	}
+	attrs := r.Metadata().Attrs
+	a := &op.Aggregate{R: r}
+	if sc.GroupBy != nil {
+		if len(sc.GroupBy.Es) != 1 {
+			return nil, nil
+		}
+		name, ok := columnName(sc.GroupBy.Es[0])
+		if !ok {
+			return nil, nil
+		}
+		if attr, ok := attribute(name, attrs); !ok || !attr.Index || attr.Type != types.T_string {
+			return nil, nil
+		}
+		a.Group = name
+	}
+	for _, sel := range sc.Sel {
+		if name, ok := columnName(sel.E); ok && len(a.Group) > 0 && name == a.Group {
+			a.As = append(a.As, op.Aggregation{IsG: true})
+			continue
+		}
+		e, ok := sel.E.(*tree.FuncExpr)
+		if !ok || len(e.Es) != 1 {
+			return nil, nil
+		}
+		fn, ok := IndexAggFuncs[strings.ToLower(e.Name)]
+		if !ok {
+			return nil, nil
+		}
+		if _, ok := e.Es[0].(*tree.StarExpr); ok {
+			if fn != index.Count {
+				return nil, nil
+			}
+			a.As = append(a.As, op.Aggregation{Op: fn})
+			continue
+		}
+		name, ok := columnName(e.Es[0])
+		if !ok {
+			return nil, nil
+		}
+		attr, ok := attribute(name, attrs)
+		if !ok || !isIndexAggType(fn, attr.Type) {
+			return nil, nil
+		}
+		a.As = append(a.As, op.Aggregation{Op: fn, Attr: name})
+	}
+	return a, nil
+}
+
+// isGrouped returns true if n has group by, having or aggregations.
+func isGrouped(n *tree.SelectClause) bool {
+	if n.GroupBy != nil || n.Having != nil {
+		return true
+	}
+	for _, sel := range n.Sel {
+		e, ok := sel.E.(*tree.FuncExpr)
+		if !ok {
+			continue
+		}
+		if _, ok := AggFuncs[strings.ToLower(e.Name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isIndexAggType(fn int, typ uint32) bool {
+	switch typ {
+	case types.T_int8, types.T_int16, types.T_int32, types.T_int64:
+	case types.T_uint8, types.T_uint16, types.T_uint32, types.T_uint64:
+	case types.T_float32, types.T_float64:
+	case types.T_timestamp:
+		return fn != index.Sum && fn != index.Avg
+	default:
+		return false
+	}
+	return true
+}
+
+func columnName(n tree.ExprStatement) (string, bool) {
+	ns, ok := n.(tree.ColunmNameList)
+	if !ok || len(ns) != 1 || ns[0].Index != nil {
+		return "", false
+	}
+	return string(ns[0].Path), true
+}
+
+func attribute(name string, attrs []metadata.Attribute) (metadata.Attribute, bool) {
+	for _, attr := range attrs {
+		if attr.Name == name {
+			return attr, true
+		}
+	}
+	return metadata.Attribute{}, false
+}
+
 var AggFuncs map[string]struct{} = map[string]struct{}{
 	"avg":   struct{}{},
 	"max":   struct{}{},
@@ -7,3 +130,12 @@ var AggFuncs map[string]struct{} = map[string]struct{}{
 	"sum":   struct{}{},
 	"count": struct{}{},
 }
+
+// IndexAggFuncs maps the aggregate functions to the aggregations of index.
+var IndexAggFuncs map[string]int = map[string]int{
+	"avg":   index.Avg,
+	"max":   index.Max,
+	"min":   index.Min,
+	"sum":   index.Sum,
+	"count": index.Count,
+}
@@ -0,0 +1,59 @@
+package build
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+)
+
+func TestAggregate(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	tests := []struct {
+		sql  string
+		want [][]string
+	}{
+		{"select count(*), sum(age), min(age), max(age), avg(age) from user", [][]string{{"6", "210", "10", "60", "35.0"}}},
+		{"select count(age), sum(age) from user where age > 30", [][]string{{"3", "150"}}},
+		{"select count(*), sum(age), min(age), max(age), avg(age) from user where age > 100", [][]string{{"0", "NULL", "NULL", "NULL", "NULL"}}},
+		{"select city, count(*), sum(age) from user group by city", [][]string{{"bj", "3", "90"}, {"sh", "3", "120"}}},
+		{"select city, max(age) from user where age < 30 group by city", [][]string{{"bj", "10"}, {"sh", "20"}}},
+	}
+	for _, test := range tests {
+		o, err := New(test.sql, e.ctx, e.stg).Build()
+		if err != nil {
+			t.Errorf("%s: %v", test.sql, err)
+			continue
+		}
+		if o.A == nil {
+			t.Errorf("%s is not answered by the index", test.sql)
+			continue
+		}
+		rs, err := o.Result(logger.New(ioutil.Discard, ""), &op.Config{Dim: 2}, e.vs, e.cli, e.vec)
+		if err != nil {
+			t.Errorf("%s: %v", test.sql, err)
+			continue
+		}
+		if !reflect.DeepEqual(rs, test.want) {
+			t.Errorf("%s = %v, want %v", test.sql, rs, test.want)
+		}
+	}
+	for _, sql := range []string{
+		"select count(*) from user order by age",
+		"select sum(name) from user",
+		"select count(*) from user group by age",
+		"select count(*) from user having count(*) > 1",
+	} {
+		o, err := New(sql, e.ctx, e.stg).Build()
+		if err != nil {
+			t.Errorf("%s: %v", sql, err)
+			continue
+		}
+		if o.A != nil {
+			t.Errorf("%s is answered by the index, want clickhouse", sql)
+		}
+	}
+}
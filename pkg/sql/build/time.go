@@ -0,0 +1,128 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend/overload"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
+)
+
+// buildExprInterval builds 'left + interval' and 'left - interval' as
+// addSeconds or addMonths, and folds them if left is a constant, the
+// time string of left is parsed as a timestamp.
+func (b *build) buildExprInterval(left tree.ExprStatement, n *tree.IntervalExpr, sign int64, id string) (extend.Extend, error) {
+	e, err := b.buildExpr(left, id)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := e.(*value.String); ok {
+		e = castValue(types.T_timestamp, v)
+	}
+	if e.ReturnType() != types.T_timestamp {
+		return nil, fmt.Errorf("'%s' must be added to or subtracted from a datetime", n)
+	}
+	x, ok := n.N.E.(*value.Int)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not integer", n.N)
+	}
+	unit := strings.ToLower(n.Unit)
+	if k, ok := IntervalSeconds[unit]; ok {
+		return reduce(&extend.BinaryExtend{
+			Op:    overload.AddSeconds,
+			Left:  e,
+			Right: value.NewInt(sign * k * int64(*x)),
+		}), nil
+	}
+	if k, ok := IntervalMonths[unit]; ok {
+		return reduce(&extend.BinaryExtend{
+			Op:    overload.AddMonths,
+			Left:  e,
+			Right: value.NewInt(sign * k * int64(*x)),
+		}), nil
+	}
+	return nil, fmt.Errorf("unsupport unit '%s'", n.Unit)
+}
+
+// bindInterval checks the unit of interval.
+func bindInterval(n *tree.IntervalExpr) (tree.ExprStatement, error) {
+	unit := strings.ToLower(n.Unit)
+	if _, ok := IntervalSeconds[unit]; ok {
+		return &tree.IntervalExpr{N: n.N, Unit: unit}, nil
+	}
+	if _, ok := IntervalMonths[unit]; ok {
+		return &tree.IntervalExpr{N: n.N, Unit: unit}, nil
+	}
+	return nil, fmt.Errorf("unsupport unit '%s'", n.Unit)
+}
+
+// reduce folds the date and time functions of constants, so that the
+// comparisons with them can be searched by the indexes.
+func reduce(e extend.Extend) extend.Extend {
+	switch v := e.(type) {
+	case *extend.UnaryExtend:
+		if _, ok := TimeFuncs[v.Op]; !ok || !isConstant(v.E) {
+			return e
+		}
+	case *extend.BinaryExtend:
+		if _, ok := TimeFuncs[v.Op]; !ok || !isConstant(v.Left) || !isConstant(v.Right) {
+			return e
+		}
+	default:
+		return e
+	}
+	vs, _, err := e.Eval(map[string]value.Values{"": static.NewBools([]bool{false}, nil, nil)})
+	if err != nil {
+		return e
+	}
+	switch v := vs.(type) {
+	case *static.Timestamps:
+		r := value.Timestamp(v.Vs[0])
+		return &r
+	case *static.Uint8s:
+		return value.NewUint8(v.Vs[0])
+	case *static.Uint16s:
+		return value.NewUint16(v.Vs[0])
+	}
+	return e
+}
+
+func isConstant(e extend.Extend) bool {
+	_, ok := e.(value.Value)
+	return ok
+}
+
+// TimeFuncs are the date and time functions which can be folded.
+var TimeFuncs map[int]struct{} = map[int]struct{}{
+	overload.ToDate:       struct{}{},
+	overload.ToYear:       struct{}{},
+	overload.ToMonth:      struct{}{},
+	overload.ToDayOfMonth: struct{}{},
+	overload.ToDayOfWeek:  struct{}{},
+	overload.ToHour:       struct{}{},
+	overload.ToMinute:     struct{}{},
+	overload.ToSecond:     struct{}{},
+	overload.DateTrunc:    struct{}{},
+	overload.AddSeconds:   struct{}{},
+	overload.AddMonths:    struct{}{},
+}
+
+// IntervalSeconds are the units of interval counted in seconds.
+var IntervalSeconds map[string]int64 = map[string]int64{
+	"second": 1,
+	"minute": 60,
+	"hour":   3600,
+	"day":    86400,
+	"week":   604800,
+}
+
+// IntervalMonths are the units of interval counted in months.
+var IntervalMonths map[string]int64 = map[string]int64{
+	"month":   1,
+	"quarter": 3,
+	"year":    12,
+}
@@ -0,0 +1,106 @@
+package build
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+func buildSelectExpr(q string) (extend.Extend, error) {
+	n, err := parser.Parse("select " + q + " from t")
+	if err != nil {
+		return nil, err
+	}
+	c := attributes{"ts": types.T_timestamp, "a": types.T_int64}
+	return New("", c, nil).buildExpr(n.Relation.(*tree.SelectClause).Sel[0].E, "t")
+}
+
+func TestInterval(t *testing.T) {
+	tests := []struct {
+		q    string
+		want string // empty if the build fails
+	}{
+		{"'2020-01-15 10:00:00' + interval 30 second", "2020-01-15 10:00:30"},
+		{"'2020-01-15 10:00:00' - interval 90 minute", "2020-01-15 08:30:00"},
+		{"'2020-01-15 10:00:00' + interval 2 hour", "2020-01-15 12:00:00"},
+		{"'2020-01-15 10:00:00' - interval 15 day", "2019-12-31 10:00:00"},
+		{"'2020-01-15 10:00:00' + interval 1 week", "2020-01-22 10:00:00"},
+		{"'2020-01-31 10:00:00' + interval 1 month", "2020-02-29 10:00:00"},
+		{"'2020-03-31 10:00:00' - interval 1 month", "2020-02-29 10:00:00"},
+		{"'2020-01-31 10:00:00' + interval 1 quarter", "2020-04-30 10:00:00"},
+		{"'2020-02-29 10:00:00' + interval 1 year", "2021-02-28 10:00:00"},
+		{"'2020-01-15 10:00:00' + interval -1 quarter", "2019-10-15 10:00:00"},
+		{"'2020-01-15 10:00:00' + interval 1 DAY", "2020-01-16 10:00:00"},
+		{"'2020-01-15 10:00:00' + interval 1 day - interval 1 hour", "2020-01-16 09:00:00"},
+		{"toHour('2020-01-15 10:00:00' + interval 30 minute)", "10"},
+		{"ts - interval 2 hour", "addSeconds(ts, -7200)"},
+		{"ts + interval 1 month", "addMonths(ts, 1)"},
+		{"ts > '2020-01-15 10:00:00' - interval 1 day", "ts > 2020-01-14 10:00:00"},
+		{"'2020-01-15 10:00:00' + interval 1 fortnight", ""},
+		{"'2020-01-15' + interval 1 day", ""},
+		{"a + interval 1 day", ""},
+		{"interval 1 day", ""},
+	}
+	for _, test := range tests {
+		e, err := buildSelectExpr(test.q)
+		switch {
+		case len(test.want) == 0 && err == nil:
+			t.Errorf("%s = %s, want error", test.q, e)
+		case len(test.want) > 0 && err != nil:
+			t.Errorf("%s: %v", test.q, err)
+		case len(test.want) > 0 && e.String() != test.want:
+			t.Errorf("%s = %s, want %s", test.q, e, test.want)
+		}
+	}
+}
+
+func TestIntervalOfNow(t *testing.T) {
+	e, err := buildSelectExpr("now() - interval 7 day")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ok := e.(*value.Timestamp)
+	if !ok {
+		t.Fatalf("now() - interval 7 day = %s, is not folded", e)
+	}
+	if d := time.Now().AddDate(0, 0, -7).Unix() - int64(*v); d < 0 || d > 5 {
+		t.Fatalf("now() - interval 7 day = %s", e)
+	}
+}
+
+func TestDateTrunc(t *testing.T) {
+	defer func(loc *time.Location) { value.Location = loc }(value.Location)
+	tests := []struct {
+		zone string
+		q    string
+		want string
+	}{
+		{"UTC", "date_trunc('minute', '2020-01-15 10:45:30' + interval 0 second)", "2020-01-15 10:45:00"},
+		{"UTC", "date_trunc('hour', '2020-01-15 10:45:30' + interval 0 second)", "2020-01-15 10:00:00"},
+		{"UTC", "date_trunc('week', '2020-01-15 10:45:30' + interval 0 second)", "2020-01-13 00:00:00"},
+		{"UTC", "date_trunc('quarter', '2020-05-15 10:45:30' + interval 0 second)", "2020-04-01 00:00:00"},
+		// the offset of Asia/Kolkata is 5:30, and Asia/Kathmandu is 5:45
+		{"Asia/Kolkata", "date_trunc('hour', '2020-01-15 10:45:30' + interval 0 second)", "2020-01-15 10:00:00"},
+		{"Asia/Kolkata", "date_trunc('hour', '2020-01-15 10:15:30' + interval 0 second)", "2020-01-15 10:00:00"},
+		{"Asia/Kathmandu", "date_trunc('hour', '2020-01-15 10:30:30' + interval 0 second)", "2020-01-15 10:00:00"},
+		{"Asia/Kathmandu", "date_trunc('minute', '2020-01-15 10:30:30' + interval 0 second)", "2020-01-15 10:30:00"},
+		{"Asia/Kolkata", "date_trunc('day', '2020-01-15 02:45:30' + interval 0 second)", "2020-01-15 00:00:00"},
+	}
+	for _, test := range tests {
+		if err := value.SetLocation(test.zone); err != nil {
+			t.Fatal(err)
+		}
+		e, err := buildSelectExpr(test.q)
+		switch {
+		case err != nil:
+			t.Errorf("%s in %s: %v", test.q, test.zone, err)
+		case e.String() != test.want:
+			t.Errorf("%s in %s = %s, want %s", test.q, test.zone, e, test.want)
+		}
+	}
+}
@@ -1,12 +1,56 @@
 package build
 
 import (
+	"sync"
+
+	"github.com/deepfabric/vectorsql/pkg/lru"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
 	"github.com/deepfabric/vectorsql/pkg/storage"
 	"github.com/deepfabric/vectorsql/pkg/vm/context"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter"
 )
 
+// MaxFilters is the maximum number of filters cached by a prepared statement,
+// the least recently used filters are evicted.
+const MaxFilters = 1024
+
+// TableOptions are the options of the table of clickhouse, the partition
+// is omitted if it is empty.
+type TableOptions struct {
+	Engine    string
+	Partition string
+	Order     string
+}
+
+var DefaultTableOptions = TableOptions{
+	Engine:    "ReplacingMergeTree()",
+	Partition: "intDiv(uid, 1000000)",
+	Order:     "(uid)",
+}
+
 type build struct {
+	rd   *float32        // radius of distance range search
+	fs   []filter.Filter // filters of joined relations and subqueries
+	top  bool            // the query has top, the ranking functions are allowed in select and having
+	sim  bool            // similarity() is used
+	stmt *Stmt           // prepared statement caching the filters
+	sql  string
+	c    context.Context
+	stg  storage.Storage
+}
+
+// Stmt is a prepared statement, the parsed statement and the filters
+// optimized for the arguments are reused by the executions.
+type Stmt struct {
+	sync.Mutex
 	sql string
+	n   *tree.Select
 	c   context.Context
 	stg storage.Storage
+	fs  lru.LRU // filters keyed by relation and condition
+}
+
+type filters struct {
+	cf filter.Filter
+	ef filter.Filter
 }
@@ -0,0 +1,84 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/op"
+)
+
+// buildUnion builds every branch of union as an op, the top and
+// ordering of n apply to the union of branches.
+func (b *build) buildUnion(n *tree.Select, u *tree.UnionClause) (*op.OP, error) {
+	var o op.OP
+
+	rs, err := unionBranches(u, u.All)
+	if err != nil {
+		return nil, err
+	}
+	var id string
+	for i, r := range rs {
+		rid, uo, err := b.buildQuery(r)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			id = rid
+		} else if len(uo.N.Relation.(*tree.SelectClause).Sel) != len(o.Us[0].N.Relation.(*tree.SelectClause).Sel) {
+			return nil, fmt.Errorf("each query of '%s' must have the same number of columns", u)
+		}
+		o.Us = append(o.Us, uo)
+	}
+	o.N = n
+	o.All = u.All
+	o.L = o.Us[0].L
+	switch t := n.Order.(type) {
+	case tree.OrderBy:
+		for _, o := range t {
+			if err := b.buildFuncs(o.E, id); err != nil {
+				return nil, err
+			}
+		}
+	case *tree.Top:
+		if len(t.Order) > 0 {
+			return nil, fmt.Errorf("'%s' of union unsupport now", t)
+		}
+		if o.T, err = b.buildOrder(n, t, id); err != nil {
+			return nil, err
+		}
+	case *tree.Ftop:
+		return nil, fmt.Errorf("'%s' of union unsupport now", t)
+	}
+	if b.rd != nil {
+		if o.T == nil {
+			o.T = &op.Top{}
+		}
+		o.T.IsR = true
+		o.T.Radius = *b.rd
+	}
+	return &o, nil
+}
+
+// unionBranches returns the branches of union in order.
+func unionBranches(n tree.RelationStatement, all bool) ([]tree.RelationStatement, error) {
+	u, ok := n.(*tree.UnionClause)
+	if !ok {
+		return []tree.RelationStatement{n}, nil
+	}
+	if u.Type != tree.UnionOp {
+		return nil, fmt.Errorf("'%s' unsupport now", u.Type)
+	}
+	if u.All != all {
+		return nil, errors.New("mixing union and union all unsupport now")
+	}
+	ls, err := unionBranches(u.Left, all)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := unionBranches(u.Right, all)
+	if err != nil {
+		return nil, err
+	}
+	return append(ls, rs...), nil
+}
@@ -0,0 +1,32 @@
+package build
+
+import "testing"
+
+func TestUnion(t *testing.T) {
+	e := newEnv(t)
+	defer e.close()
+	queryTests(t, e, []struct{ sql, want string }{
+		{
+			"select uid from user where city = 'sh' and age > 20 union select uid from user where age < 20",
+			"SELECT uid FROM user_item WHERE uid IN [1, 4, 6]",
+		},
+		{
+			"select uid from user where city = 'sh' union select uid from user where city = 'bj' fetch first 2 rows only",
+			"SELECT * FROM (SELECT uid FROM user_item WHERE uid IN [1, 2, 3, 4, 5, 6]) LIMIT 2",
+		},
+		{
+			"select uid from user where age > 40 union all select uid from user where age > 50",
+			"SELECT uid FROM user_item WHERE uid IN [5, 6] UNION ALL SELECT uid FROM user_item WHERE uid IN [6]",
+		},
+		{
+			"select uid, age + 1 from user where city = 'sh' union select uid, age * 2 from user where city = 'bj' top 3 fetch first 2 rows only",
+			"SELECT uid, _1 FROM (WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, age + 1 AS _1 FROM user_item WHERE xid IN [34359738368] UNION DISTINCT WITH [17179869184, 34359738368, 51539607552] AS xids SELECT indexOf(xids, xid) AS no, uid, age * 2 AS _1 FROM user_item WHERE xid IN [17179869184, 51539607552]) ORDER BY no LIMIT 2",
+		},
+		{
+			"select uid, age + 1 as a from user where city = 'sh' union all select uid, age from user where age > 30 top 2",
+			"SELECT uid, a FROM (WITH [34359738368, 68719476736] AS xids SELECT indexOf(xids, xid) AS no, uid, age + 1 AS a FROM user_item WHERE xid IN [34359738368, 68719476736] UNION ALL WITH [34359738368, 68719476736] AS xids SELECT indexOf(xids, xid) AS no, uid, age FROM user_item WHERE xid IN [68719476736]) ORDER BY no",
+		},
+		{"select uid from user union select uid, age from user", ""},
+		{"select uid from user union select uid from user ftop 2", ""},
+	})
+}
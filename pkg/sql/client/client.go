@@ -1,18 +1,26 @@
 package client
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"log"
 	"reflect"
+	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
 
 	_ "github.com/ClickHouse/clickhouse-go"
 )
 
+// NullField is the text of null value in the rows.
+const NullField = "NULL"
+
 func New(dsn string) (*client, error) {
 	db, err := sql.Open("clickhouse", dsn)
 	if err != nil {
@@ -34,14 +42,24 @@ func (c *client) Query(query string) ([][]string, error) {
 		return nil, err
 	}
 	defer rows.Close()
-	attrs, err := rows.Columns()
+	attrs, err := rows.ColumnTypes()
 	if err != nil {
 		return nil, err
 	}
+	arrays := make([]interface{}, len(attrs)) // array attributes, such as vector
+	times := make([]interface{}, len(attrs))  // datetime attributes
 	values := make([]sql.RawBytes, len(attrs))
 	scanArgs := make([]interface{}, len(values))
 	for i := range values {
-		scanArgs[i] = &values[i]
+		name := strings.TrimPrefix(attrs[i].DatabaseTypeName(), "Nullable(")
+		switch {
+		case strings.HasPrefix(name, "Array("):
+			scanArgs[i] = &arrays[i]
+		case strings.HasPrefix(name, "DateTime"):
+			scanArgs[i] = &times[i]
+		default:
+			scanArgs[i] = &values[i]
+		}
 	}
 	for rows.Next() {
 		if err = rows.Scan(scanArgs...); err != nil {
@@ -50,9 +68,14 @@ func (c *client) Query(query string) ([][]string, error) {
 		var v string
 		r := make([]string, len(attrs))
 		for i, col := range values {
-			if col == nil {
-				v = "NULL"
-			} else {
+			switch {
+			case arrays[i] != nil:
+				v = arrayToString(arrays[i])
+			case times[i] != nil:
+				v = timeToString(times[i])
+			case col == nil:
+				v = NullField
+			default:
 				v = string(col)
 			}
 			r[i] = v
@@ -125,6 +148,32 @@ func unmarshalBitMap(data []byte) (*roaring.Bitmap, error) {
 	return nil, nil
 }
 
+func arrayToString(v interface{}) string {
+	var buf bytes.Buffer
+
+	xs := reflect.ValueOf(v)
+	if xs.Kind() != reflect.Slice {
+		return fmt.Sprintf("%v", v)
+	}
+	buf.WriteByte('[')
+	for i, n := 0, xs.Len(); i < n; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprintf("%v", xs.Index(i).Interface()))
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+// timeToString formats the datetime v in the time zone of value.Location.
+func timeToString(v interface{}) string {
+	if t, ok := v.(time.Time); ok {
+		return t.In(value.Location).Format(value.TimestampOutputFormat)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
 func decodeVector(v []byte) []uint32 {
 	hp := *(*reflect.SliceHeader)(unsafe.Pointer(&v))
 	hp.Len /= 4
@@ -0,0 +1,73 @@
+package dialect
+
+import (
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+)
+
+// Candidates returns the query n over the candidates xids of vector
+// search whose rows satisfy cond. xids are given as the array xids and
+// the similarities of candidates as the array scores, scores are only
+// given if they are used by n.
+func Candidates(n *tree.SelectClause, cond string, xids []uint64, scores []float32) string {
+	s := "WITH " + Uint64s(xids) + " AS xids"
+	if scores != nil {
+		s += ", " + Float32s(scores) + " AS scores"
+	}
+	return s + " " + SelectWhere(n, cond)
+}
+
+// InCandidates returns the condition of the rows of candidates, whose
+// uids are also in is if is is not empty.
+func InCandidates(is []uint32) string {
+	if len(is) == 0 {
+		return "xid IN xids"
+	}
+	return "xid IN xids AND " + InUids(is)
+}
+
+// InUids returns the condition of the rows whose uids are in is.
+func InUids(is []uint32) string {
+	return "uid IN " + Uint32s(is)
+}
+
+// InXids returns the condition of the rows whose xids are in xs.
+func InXids(xs []uint64) string {
+	return "xid IN " + Uint64s(xs)
+}
+
+// OrderByCandidates returns the ordering of candidates by n, the ties
+// are broken by no, the rank of candidates selected by the subquery.
+func OrderByCandidates(n tree.OrderBy) string {
+	if len(n) == 0 {
+		return "ORDER BY no"
+	}
+	return OrderBy(n) + ", no"
+}
+
+// OrderByGroups returns the ordering of the groups of candidates by n,
+// the ties are broken by the best rank of the candidates of group.
+func OrderByGroups(n tree.OrderBy) string {
+	rank := "min(" + Expr(&tree.Index{}) + ")"
+	if len(n) == 0 {
+		return "ORDER BY " + rank
+	}
+	return OrderBy(n) + ", " + rank
+}
+
+// SelectFrom returns the query selecting the select list of n out of
+// the subquery sql, only the distinct and the select list of n are used.
+func SelectFrom(n *tree.SelectClause, sql string) string {
+	sel := tree.SelectClause{Distinct: n.Distinct, Sel: n.Sel}
+	return SelectWhere(&sel, "") + " FROM (" + sql + ")"
+}
+
+// Union returns the union of the queries ss, the duplicate rows are
+// removed unless all is true.
+func Union(ss []string, all bool) string {
+	if all {
+		return strings.Join(ss, " UNION ALL ")
+	}
+	return strings.Join(ss, " UNION DISTINCT ")
+}
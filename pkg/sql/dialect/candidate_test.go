@@ -0,0 +1,80 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+)
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		sql    string
+		cond   string
+		scores []float32
+		want   string
+	}{
+		{"select a from t", InCandidates(nil), nil, "WITH [1, 2] AS xids SELECT a FROM t WHERE xid IN xids"},
+		{"select a from t", InCandidates([]uint32{3, 4}), nil, "WITH [1, 2] AS xids SELECT a FROM t WHERE xid IN xids AND uid IN [3, 4]"},
+		{"select a from t", InXids([]uint64{2}), []float32{0.5, 0.25}, "WITH [1, 2] AS xids, [0.5, 0.25] AS scores SELECT a FROM t WHERE xid IN [2]"},
+		{"select a, count(*) from t group by a", InCandidates(nil), nil, "WITH [1, 2] AS xids SELECT a, count(*) FROM t WHERE xid IN xids GROUP BY a"},
+	}
+	for _, test := range tests {
+		if s := Candidates(selectClause(t, test.sql), test.cond, []uint64{1, 2}, test.scores); s != test.want {
+			t.Errorf("Candidates(%s, %s) = %s, want %s", test.sql, test.cond, s, test.want)
+		}
+	}
+}
+
+func TestOrderByCandidates(t *testing.T) {
+	n, err := parser.Parse("select a from t order by a desc, b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ord := n.Order.(tree.OrderBy)
+	tests := []struct {
+		s, want string
+	}{
+		{OrderByCandidates(nil), "ORDER BY no"},
+		{OrderByCandidates(ord), "ORDER BY a DESC, b, no"},
+		{OrderByGroups(nil), "ORDER BY min(indexOf(xids, xid))"},
+		{OrderByGroups(ord), "ORDER BY a DESC, b, min(indexOf(xids, xid))"},
+	}
+	for _, test := range tests {
+		if test.s != test.want {
+			t.Errorf("got %s, want %s", test.s, test.want)
+		}
+	}
+}
+
+func TestSelectFrom(t *testing.T) {
+	tests := []struct {
+		sql, want string
+	}{
+		{"select a, b as c from t where a > 1", "SELECT a, b AS c FROM (SELECT 1)"},
+		{"select distinct a from t", "SELECT DISTINCT a FROM (SELECT 1)"},
+	}
+	for _, test := range tests {
+		if s := SelectFrom(selectClause(t, test.sql), "SELECT 1"); s != test.want {
+			t.Errorf("SelectFrom(%s) = %s, want %s", test.sql, s, test.want)
+		}
+	}
+	if s := SelectFrom(&tree.SelectClause{}, "SELECT 1"); s != "SELECT * FROM (SELECT 1)" {
+		t.Errorf("SelectFrom() = %s, want SELECT * FROM (SELECT 1)", s)
+	}
+	ss := []string{"SELECT 1", "SELECT 2"}
+	if s := Union(ss, true); s != "SELECT 1 UNION ALL SELECT 2" {
+		t.Errorf("Union(%v, true) = %s", ss, s)
+	}
+	if s := Union(ss, false); s != "SELECT 1 UNION DISTINCT SELECT 2" {
+		t.Errorf("Union(%v, false) = %s", ss, s)
+	}
+}
+
+func selectClause(t *testing.T, sql string) *tree.SelectClause {
+	n, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n.Relation.(*tree.SelectClause)
+}
@@ -0,0 +1,117 @@
+package dialect
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// BitmapQuery returns the query of bitmap e which combines the bitmaps
+// of bs, the result is the serialized bitmap.
+func BitmapQuery(bs []Bitmap, e string) string {
+	var buf bytes.Buffer
+
+	for i, b := range bs {
+		if i == 0 {
+			buf.WriteString("WITH ")
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprintf("(%s) AS %s", BitmapSelect(b.Table, Extend(b.E)), Ident(b.Name)))
+	}
+	buf.WriteString(fmt.Sprintf(" SELECT CAST(%s AS String) AS result", e))
+	return buf.String()
+}
+
+// BitmapSelect returns the query of the bitmap of the uids of table
+// which satisfy cond.
+func BitmapSelect(table string, cond string) string {
+	return fmt.Sprintf("SELECT groupBitmapState(uid) FROM %s WHERE %s", QualifiedIdent(table), cond)
+}
+
+// CreateTable returns the statement creating the table of relation id,
+// the table is not partitioned if partition is empty.
+func CreateTable(id string, attrs []metadata.Attribute, engine, partition, order string) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(fmt.Sprintf("CREATE TABLE %s (", QualifiedIdent(id)))
+	for i, attr := range attrs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if attr.Nullable {
+			buf.WriteString(fmt.Sprintf("%s Nullable(%s)", Ident(attr.Name), Type(attr.Type)))
+		} else {
+			buf.WriteString(fmt.Sprintf("%s %s", Ident(attr.Name), Type(attr.Type)))
+		}
+	}
+	buf.WriteString(fmt.Sprintf(") engine=%s", engine))
+	if len(partition) > 0 {
+		buf.WriteString(fmt.Sprintf(" PARTITION BY %s", partition))
+	}
+	buf.WriteString(fmt.Sprintf(" ORDER BY %s;", order))
+	return buf.String()
+}
+
+// Insert returns the statement inserting n rows into the table of
+// relation id, the values are given by the arguments of statement.
+func Insert(id string, attrs []metadata.Attribute, n int) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(fmt.Sprintf("INSERT INTO %s (", QualifiedIdent(id)))
+	for i, attr := range attrs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(Ident(attr.Name))
+	}
+	buf.WriteString(") VALUES")
+	for i := 0; i < n; i++ {
+		buf.WriteString(" (")
+		for j := range attrs {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteByte('?')
+		}
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+// Type returns the type of clickhouse of typ, it is empty if typ has
+// no column type. The datetimes are in the time zone of value.Location.
+func Type(typ uint32) string {
+	switch typ {
+	case types.T_int8:
+		return "Int8"
+	case types.T_int16:
+		return "Int16"
+	case types.T_int32:
+		return "Int32"
+	case types.T_int64:
+		return "Int64"
+	case types.T_uint8:
+		return "UInt8"
+	case types.T_uint16:
+		return "UInt16"
+	case types.T_uint32:
+		return "UInt32"
+	case types.T_uint64:
+		return "UInt64"
+	case types.T_float32:
+		return "Float32"
+	case types.T_float64:
+		return "Float64"
+	case types.T_string:
+		return "String"
+	case types.T_timestamp:
+		return "DateTime(" + Quote(value.Location.String()) + ")"
+	case types.T_vector:
+		return "Array(Float32)"
+	}
+	return ""
+}
@@ -0,0 +1,303 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+)
+
+// Select returns the query of n.
+func Select(n *tree.Select) string {
+	s := Relation(n.Relation)
+	if n.Order != nil {
+		ord, ok := n.Order.(tree.OrderBy)
+		if !ok {
+			panic(fmt.Errorf("unexpected ordering '%s'", n.Order))
+		}
+		s += " " + OrderBy(ord)
+	}
+	if n.Limit != nil {
+		s += " " + Limit(n.Limit)
+	}
+	return s
+}
+
+// SelectWhere returns the query of n whose where clause is replaced by
+// cond, the where clause of n is used if cond is empty.
+func SelectWhere(n *tree.SelectClause, cond string) string {
+	s := "SELECT "
+	if n.Distinct {
+		s += "DISTINCT "
+	}
+	if len(n.Sel) > 0 {
+		s += SelectExprs(n.Sel)
+	} else {
+		s += "*"
+	}
+	if n.From != nil {
+		s += " FROM " + Tables(n.From.Tables)
+	}
+	switch {
+	case len(cond) > 0:
+		s += " WHERE " + cond
+	case n.Where != nil:
+		s += " WHERE " + Expr(n.Where.E)
+	}
+	if n.GroupBy != nil {
+		s += " GROUP BY " + Exprs(n.GroupBy.Es)
+	}
+	if n.Having != nil {
+		s += " HAVING " + Expr(n.Having.E)
+	}
+	return s
+}
+
+// Relation returns the query of relation n.
+func Relation(n tree.RelationStatement) string {
+	switch r := n.(type) {
+	case *tree.SelectClause:
+		return SelectWhere(r, "")
+	case *tree.UnionClause:
+		s := Relation(r.Left) + " " + r.Type.String()
+		if r.All {
+			s += " ALL"
+		} else if r.Type == tree.UnionOp {
+			s += " DISTINCT"
+		}
+		return s + " " + Relation(r.Right)
+	case *tree.JoinClause:
+		s := Relation(r.Left) + " " + r.Type.String() + " " + Relation(r.Right)
+		if c, ok := r.Cond.(*tree.OnJoinCond); ok {
+			s += " ON " + Expr(c.E)
+		}
+		return s
+	case *tree.AliasedSelect:
+		s := "(" + Select(r.Sel) + ")"
+		if r.As != nil {
+			s += " AS " + Ident(string(r.As.Alias))
+		}
+		return s
+	case *tree.TableName:
+		return Table(r)
+	case *tree.AliasedTable:
+		return Table(r)
+	}
+	panic(fmt.Errorf("unexpected relation '%s'", n))
+}
+
+// Tables returns the tables of from clause.
+func Tables(ns tree.TableStatements) string {
+	var s string
+
+	for i, n := range ns {
+		if i > 0 {
+			s += ", "
+		}
+		s += Table(n)
+	}
+	return s
+}
+
+// Table returns the table n.
+func Table(n tree.TableStatement) string {
+	switch t := n.(type) {
+	case *tree.TableName:
+		return Columns(t.N)
+	case *tree.AliasedTable:
+		s := Table(t.Tbl)
+		if t.As != nil {
+			s += " AS " + Ident(string(t.As.Alias))
+		}
+		return s
+	case *tree.Subquery:
+		return Expr(t)
+	case *tree.JoinTable:
+		var s string
+
+		for i, name := range t.Using {
+			if i > 0 {
+				s += ", "
+			}
+			s += Ident(string(name))
+		}
+		return fmt.Sprintf("%s %s %s USING (%s)", Table(t.Left), t.Type, Table(t.Right), s)
+	}
+	panic(fmt.Errorf("unexpected table '%s'", n))
+}
+
+// SelectExprs returns the select list ns.
+func SelectExprs(ns tree.SelectExprs) string {
+	var s string
+
+	for i, n := range ns {
+		if i > 0 {
+			s += ", "
+		}
+		s += Expr(n.E)
+		if len(n.As) > 0 {
+			s += " AS " + Ident(string(n.As))
+		}
+	}
+	return s
+}
+
+// OrderBy returns the order by clause of n.
+func OrderBy(n tree.OrderBy) string {
+	s := "ORDER BY "
+	for i, ord := range n {
+		if i > 0 {
+			s += ", "
+		}
+		s += Expr(ord.E)
+		if ord.Type != tree.DefaultDirection {
+			s += " " + ord.Type.String()
+		}
+	}
+	return s
+}
+
+// Limit returns the limit clause of n.
+func Limit(n *tree.Limit) string {
+	var s string
+
+	if n.Count != nil {
+		s += "LIMIT " + Expr(n.Count)
+	}
+	if n.Offset != nil {
+		if len(s) > 0 {
+			s += " "
+		}
+		s += "OFFSET " + Expr(n.Offset)
+	}
+	return s
+}
+
+// Exprs returns the list of expressions ns.
+func Exprs(ns tree.ExprStatements) string {
+	var s string
+
+	for i, n := range ns {
+		if i > 0 {
+			s += ", "
+		}
+		s += Expr(n)
+	}
+	return s
+}
+
+// Columns returns the column or table ns.
+func Columns(ns tree.ColunmNameList) string {
+	var s string
+
+	for i, n := range ns {
+		if i > 0 {
+			s += "."
+		}
+		s += Ident(string(n.Path))
+		if n.Index != nil {
+			s += "[" + Expr(n.Index) + "]"
+		}
+	}
+	return s
+}
+
+// Expr returns the expression n.
+func Expr(n tree.ExprStatement) string {
+	switch e := n.(type) {
+	case *tree.Value:
+		return Value(e.E)
+	case *tree.Index:
+		return "indexOf(xids, xid)"
+	case *tree.NotExpr:
+		return "NOT " + Expr(e.E)
+	case *tree.OrExpr:
+		return binary(e.Left, "OR", e.Right)
+	case *tree.AndExpr:
+		return binary(e.Left, "AND", e.Right)
+	case *tree.DivExpr:
+		return binary(e.Left, "/", e.Right)
+	case *tree.ModExpr:
+		return binary(e.Left, "%", e.Right)
+	case *tree.MultExpr:
+		return binary(e.Left, "*", e.Right)
+	case *tree.PlusExpr:
+		return binary(e.Left, "+", e.Right)
+	case *tree.MinusExpr:
+		return binary(e.Left, "-", e.Right)
+	case *tree.UnaryMinusExpr:
+		return "-" + Expr(e.E)
+	case *tree.EqExpr:
+		return binary(e.Left, "=", e.Right)
+	case *tree.NeExpr:
+		return binary(e.Left, "<>", e.Right)
+	case *tree.LtExpr:
+		return binary(e.Left, "<", e.Right)
+	case *tree.LeExpr:
+		return binary(e.Left, "<=", e.Right)
+	case *tree.GtExpr:
+		return binary(e.Left, ">", e.Right)
+	case *tree.GeExpr:
+		return binary(e.Left, ">=", e.Right)
+	case *tree.BetweenExpr:
+		return fmt.Sprintf("%s BETWEEN %s AND %s", Expr(e.E), Expr(e.From), Expr(e.To))
+	case *tree.NotBetweenExpr:
+		return fmt.Sprintf("%s NOT BETWEEN %s AND %s", Expr(e.E), Expr(e.From), Expr(e.To))
+	case *tree.InExpr:
+		return Expr(e.Left) + " IN " + inList(e.Right)
+	case *tree.NotInExpr:
+		return Expr(e.Left) + " NOT IN " + inList(e.Right)
+	case *tree.IsNullExpr:
+		return Expr(e.E) + " IS NULL"
+	case *tree.IsNotNullExpr:
+		return Expr(e.E) + " IS NOT NULL"
+	case *tree.ParenExpr:
+		return "(" + Expr(e.E) + ")"
+	case *tree.StarExpr:
+		return "*"
+	case *tree.FuncExpr:
+		return Ident(e.Name) + "(" + Exprs(e.Es) + ")"
+	case *tree.CaseExpr:
+		return caseExpr(e)
+	case *tree.IntervalExpr:
+		return fmt.Sprintf("INTERVAL %s %s", Expr(e.N), strings.ToUpper(e.Unit))
+	case *tree.Subquery:
+		if e.Exists {
+			return "EXISTS (" + Select(e.Select) + ")"
+		}
+		return "(" + Select(e.Select) + ")"
+	case tree.ExprStatements:
+		return Exprs(e)
+	case tree.ColunmNameList:
+		return Columns(e)
+	case tree.ColunmName:
+		return Columns(tree.ColunmNameList{e})
+	}
+	panic(fmt.Errorf("unexpected expression '%s'", n))
+}
+
+// inList returns the subquery or the parenthesized list of values.
+func inList(n tree.ExprStatement) string {
+	if es, ok := n.(tree.ExprStatements); ok {
+		return "(" + Exprs(es) + ")"
+	}
+	return Expr(n)
+}
+
+func binary(left tree.ExprStatement, op string, right tree.ExprStatement) string {
+	return Expr(left) + " " + op + " " + Expr(right)
+}
+
+func caseExpr(e *tree.CaseExpr) string {
+	s := "CASE"
+	if e.E != nil {
+		s += " " + Expr(e.E)
+	}
+	for _, w := range e.Whens {
+		s += fmt.Sprintf(" WHEN %s THEN %s", Expr(w.Cond), Expr(w.Val))
+	}
+	if e.Else != nil {
+		s += " ELSE " + Expr(e.Else)
+	}
+	return s + " END"
+}
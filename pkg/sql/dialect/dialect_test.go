@@ -0,0 +1,70 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/parser"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"", "''"},
+		{"abc", "'abc'"},
+		{"上海", "'上海'"},
+		{"it's", `'it\'s'`},
+		{`a\b`, `'a\\b'`},
+		{"a\x00b", `'a\0b'`},
+		{"a.b", "'a.b'"},
+	}
+	for _, test := range tests {
+		if s := Quote(test.s); s != test.want {
+			t.Errorf("Quote(%q) = %s, want %s", test.s, s, test.want)
+		}
+	}
+}
+
+func TestIdent(t *testing.T) {
+	tests := []struct {
+		name, ident, qualified string
+	}{
+		{"age", "age", "age"},
+		{"_a1", "_a1", "_a1"},
+		{"1a", "`1a`", "`1a`"},
+		{"", "``", "``"},
+		{"user name", "`user name`", "`user name`"},
+		{"a`b", "`a\\`b`", "`a\\`b`"},
+		{`a\b`, "`a\\\\b`", "`a\\\\b`"},
+		{"a.b", "`a.b`", "a.b"},
+		{"db.user name", "`db.user name`", "db.`user name`"},
+		{"城市", "`城市`", "`城市`"},
+	}
+	for _, test := range tests {
+		if s := Ident(test.name); s != test.ident {
+			t.Errorf("Ident(%q) = %s, want %s", test.name, s, test.ident)
+		}
+		if s := QualifiedIdent(test.name); s != test.qualified {
+			t.Errorf("QualifiedIdent(%q) = %s, want %s", test.name, s, test.qualified)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		sql, want string
+	}{
+		{`select "a.b" from t`, "SELECT `a.b` FROM t"},
+		{`select t."a.b" from "db.t" as t`, "SELECT t.`a.b` FROM `db.t` AS t"},
+		{`select a from db.t where "x y" = 'it''s'`, "SELECT a FROM db.t WHERE `x y` = 'it\\'s'"},
+	}
+	for _, test := range tests {
+		n, err := parser.Parse(test.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := Select(n); s != test.want {
+			t.Errorf("Select(%s) = %s, want %s", test.sql, s, test.want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/extend"
+	"github.com/deepfabric/vectorsql/pkg/vm/extend/overload"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// Extend returns the expression e.
+func Extend(e extend.Extend) string {
+	switch v := e.(type) {
+	case *extend.Attribute:
+		return QualifiedIdent(v.Name)
+	case *extend.ParenExtend:
+		return "(" + Extend(v.E) + ")"
+	case *extend.UnaryExtend:
+		return unaryExtend(v)
+	case *extend.BinaryExtend:
+		return binaryExtend(v)
+	case *extend.MultiExtend:
+		return multiExtend(v)
+	case value.Value:
+		return Value(v)
+	}
+	panic(fmt.Errorf("unexpected extend '%s'", e))
+}
+
+func unaryExtend(e *extend.UnaryExtend) string {
+	switch e.Op {
+	case overload.Not:
+		return "NOT " + Extend(e.E)
+	case overload.UnaryMinus:
+		return "-" + Extend(e.E)
+	case overload.IsNull:
+		return Extend(e.E) + " IS NULL"
+	case overload.IsNotNull:
+		return Extend(e.E) + " IS NOT NULL"
+	}
+	if name, ok := extendFuncs[e.Op]; ok {
+		return fmt.Sprintf("%s(%s)", name, Extend(e.E))
+	}
+	panic(fmt.Errorf("unexpected extend '%s'", e))
+}
+
+func binaryExtend(e *extend.BinaryExtend) string {
+	if op, ok := compareOps[e.Op]; ok {
+		l, r := Extend(e.Left), Extend(e.Right)
+		if a, ok := e.Left.(*extend.Attribute); ok {
+			if v, ok := e.Right.(value.Value); ok {
+				r = cast(a.Type, v)
+			}
+		}
+		if a, ok := e.Right.(*extend.Attribute); ok {
+			if v, ok := e.Left.(value.Value); ok {
+				l = cast(a.Type, v)
+			}
+		}
+		return fmt.Sprintf("%s %s %s", l, op, r)
+	}
+	if op, ok := extendOps[e.Op]; ok {
+		return fmt.Sprintf("%s %s %s", Extend(e.Left), op, Extend(e.Right))
+	}
+	if name, ok := extendFuncs[e.Op]; ok {
+		return fmt.Sprintf("%s(%s, %s)", name, Extend(e.Left), Extend(e.Right))
+	}
+	panic(fmt.Errorf("unexpected extend '%s'", e))
+}
+
+func multiExtend(e *extend.MultiExtend) string {
+	switch e.Op {
+	case overload.In:
+		return inExtend(e, "IN")
+	case overload.NotIn:
+		return inExtend(e, "NOT IN")
+	}
+	name, ok := extendFuncs[e.Op]
+	if !ok {
+		panic(fmt.Errorf("unexpected extend '%s'", e))
+	}
+	s := name + "("
+	for i, arg := range e.Args {
+		if i > 0 {
+			s += ", "
+		}
+		s += Extend(arg)
+	}
+	return s + ")"
+}
+
+// inExtend returns the in of the first argument of e, the constants are
+// cast like the comparisons.
+func inExtend(e *extend.MultiExtend, op string) string {
+	a, _ := e.Args[0].(*extend.Attribute)
+	s := Extend(e.Args[0]) + " " + op + " ("
+	for i, arg := range e.Args[1:] {
+		if i > 0 {
+			s += ", "
+		}
+		if v, ok := arg.(value.Value); ok && a != nil {
+			s += cast(a.Type, v)
+		} else {
+			s += Extend(arg)
+		}
+	}
+	return s + ")"
+}
+
+// cast returns the constant v compared with an attribute of type typ,
+// the untyped floats and times are cast to the type of attribute.
+func cast(typ uint32, v value.Value) string {
+	switch x := v.(type) {
+	case *value.Int:
+		switch typ {
+		case types.T_float32:
+			return fmt.Sprintf("toFloat32(%s)", x)
+		case types.T_timestamp:
+			return fmt.Sprintf("toDateTime(%s)", x)
+		}
+	case *value.Float:
+		if typ == types.T_float32 {
+			return fmt.Sprintf("toFloat32(%s)", Value(x))
+		}
+	case *value.String:
+		if typ == types.T_timestamp {
+			if t, err := value.ParseTimestamp(string(*x)); err == nil {
+				return Value(t)
+			}
+		}
+	}
+	return Value(v)
+}
+
+// compareOps are the comparison operators of binary extends.
+var compareOps = map[int]string{
+	overload.EQ: "=",
+	overload.LT: "<",
+	overload.GT: ">",
+	overload.LE: "<=",
+	overload.GE: ">=",
+	overload.NE: "<>",
+}
+
+// extendOps are the other operators of binary extends.
+var extendOps = map[int]string{
+	overload.Or:    "OR",
+	overload.And:   "AND",
+	overload.Div:   "/",
+	overload.Mod:   "%",
+	overload.Plus:  "+",
+	overload.Mult:  "*",
+	overload.Minus: "-",
+}
+
+// extendFuncs are the functions of clickhouse of extends, the type of
+// typecast is given by a string.
+var extendFuncs = map[int]string{
+	overload.Abs:            "abs",
+	overload.Ceil:           "ceil",
+	overload.Sign:           "sign",
+	overload.Floor:          "floor",
+	overload.Lower:          "lower",
+	overload.Round:          "round",
+	overload.Upper:          "upper",
+	overload.Length:         "length",
+	overload.Typeof:         "toTypeName",
+	overload.Norm:           "L2Norm",
+	overload.Normalize:      "L2Normalize",
+	overload.Typecast:       "CAST",
+	overload.Like:           "like",
+	overload.NotLike:        "notLike",
+	overload.Match:          "match",
+	overload.NotMatch:       "notMatch",
+	overload.L2Distance:     "L2Distance",
+	overload.CosineDistance: "cosineDistance",
+	overload.InnerProduct:   "dotProduct",
+	overload.Concat:         "concat",
+	overload.Coalesce:       "coalesce",
+	overload.Greatest:       "greatest",
+	overload.Least:          "least",
+	overload.If:             "if",
+	overload.MultiIf:        "multiIf",
+	overload.ToDate:         "toDate",
+	overload.ToYear:         "toYear",
+	overload.ToMonth:        "toMonth",
+	overload.ToDayOfMonth:   "toDayOfMonth",
+	overload.ToDayOfWeek:    "toDayOfWeek",
+	overload.ToHour:         "toHour",
+	overload.ToMinute:       "toMinute",
+	overload.ToSecond:       "toSecond",
+	overload.DateTrunc:      "dateTrunc",
+	overload.AddSeconds:     "addSeconds",
+	overload.AddMonths:      "addMonths",
+}
@@ -0,0 +1,11 @@
+package dialect
+
+import "github.com/deepfabric/vectorsql/pkg/vm/extend"
+
+// Bitmap is the bitmap of the uids of relation Table which satisfy E,
+// Name is the name of the bitmap in the query.
+type Bitmap struct {
+	Name  string
+	Table string
+	E     extend.Extend
+}
@@ -0,0 +1,168 @@
+package dialect
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// Quote returns the string literal of s, the quotes and backslashes
+// are escaped.
+func Quote(s string) string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case 0:
+			buf.WriteString("\\0")
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('\'')
+	return buf.String()
+}
+
+// Ident returns the identifier name quoted by backquotes unless it is a
+// plain identifier, a dot in name is a part of the identifier.
+func Ident(name string) string {
+	if isPlain(name) {
+		return name
+	}
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+// QualifiedIdent returns the qualified name whose parts are joined by
+// dots, such as the relations and the attributes of extends, every part
+// is quoted by Ident.
+func QualifiedIdent(name string) string {
+	ss := strings.Split(name, ".")
+	for i, s := range ss {
+		ss[i] = Ident(s)
+	}
+	return strings.Join(ss, ".")
+}
+
+// Value returns the literal of v, the typed numbers are cast to their
+// types and timestamps are given by unix time.
+func Value(v value.Value) string {
+	switch x := v.(type) {
+	case value.Null:
+		return "NULL"
+	case *value.String:
+		return Quote(string(*x))
+	case *value.Bool:
+		return x.String()
+	case *value.Int:
+		return x.String()
+	case *value.Float:
+		return float(float64(*x), x.String())
+	case *value.Timestamp:
+		return fmt.Sprintf("toDateTime(%v)", int64(*x))
+	case *value.Int8:
+		return fmt.Sprintf("toInt8(%s)", x)
+	case *value.Int16:
+		return fmt.Sprintf("toInt16(%s)", x)
+	case *value.Int32:
+		return fmt.Sprintf("toInt32(%s)", x)
+	case *value.Int64:
+		return fmt.Sprintf("toInt64(%s)", x)
+	case *value.Uint8:
+		return fmt.Sprintf("toUInt8(%s)", x)
+	case *value.Uint16:
+		return fmt.Sprintf("toUInt16(%s)", x)
+	case *value.Uint32:
+		return fmt.Sprintf("toUInt32(%s)", x)
+	case *value.Uint64:
+		return fmt.Sprintf("toUInt64(%s)", x)
+	case *value.Float32:
+		return fmt.Sprintf("toFloat32(%s)", float(float64(*x), x.String()))
+	case *value.Float64:
+		return fmt.Sprintf("toFloat64(%s)", float(float64(*x), x.String()))
+	case *value.Vector:
+		return Float32s(*x)
+	}
+	panic(fmt.Errorf("unexpected value '%s'", v))
+}
+
+// Uint32s returns the array literal of xs.
+func Uint32s(xs []uint32) string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	for i, x := range xs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.FormatUint(uint64(x), 10))
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+// Uint64s returns the array literal of xs.
+func Uint64s(xs []uint64) string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	for i, x := range xs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.FormatUint(x, 10))
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+// Float32s returns the array literal of xs.
+func Float32s(xs []float32) string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	for i, x := range xs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(float(float64(x), strconv.FormatFloat(float64(x), 'g', -1, 32)))
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+// float returns the literal s of f, nan and infinities have no
+// numeric literal.
+func float(f float64, s string) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	return s
+}
+
+func isPlain(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -22,8 +22,12 @@ func GetKeywordID(k string) int32 {
 		return BOOL
 	case "by":
 		return BY
+	case "case":
+		return CASE
 	case "cast":
 		return CAST
+	case "create":
+		return CREATE
 	case "cross":
 		return CROSS
 	case "desc":
@@ -50,14 +54,28 @@ func GetKeywordID(k string) int32 {
 		return GROUP
 	case "having":
 		return HAVING
+	case "in":
+		return IN
+	case "index":
+		return INDEX
 	case "inner":
 		return INNER
+	case "insert":
+		return INSERT
 	case "int":
 		return INT
 	case "intersect":
 		return INTERSECT
+	case "interval":
+		return INTERVAL
+	case "into":
+		return INTO
 	case "is":
 		return IS
+	case "else":
+		return ELSE
+	case "end":
+		return END
 	case "except":
 		return EXCEPT
 	case "join":
@@ -82,6 +100,8 @@ func GetKeywordID(k string) int32 {
 		return ORDER
 	case "outer":
 		return OUTER
+	case "rerank":
+		return RERANK
 	case "right":
 		return RIGHT
 	case "row":
@@ -92,16 +112,26 @@ func GetKeywordID(k string) int32 {
 		return SELECT
 	case "string":
 		return STRING
+	case "table":
+		return TABLE
 	case "top":
 		return TOP
+	case "then":
+		return THEN
 	case "time":
 		return TIME
 	case "true":
 		return TRUE
 	case "union":
 		return UNION
+	case "values":
+		return VALUES
+	case "when":
+		return WHEN
 	case "where":
 		return WHERE
+	case "with":
+		return WITH
 	default:
 		return IDENT
 	}
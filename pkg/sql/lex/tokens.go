@@ -5,57 +5,73 @@ const IDENT = 57346
 const ICONST = 57347
 const FCONST = 57348
 const SCONST = 57349
-const LESS_EQUALS = 57350
-const GREATER_EQUALS = 57351
-const NOT_EQUALS = 57352
-const ALL = 57353
-const AND = 57354
-const AS = 57355
-const ASC = 57356
-const BETWEEN = 57357
-const BOOL = 57358
-const BY = 57359
-const CAST = 57360
-const CROSS = 57361
-const DESC = 57362
-const DISTINCT = 57363
-const EXCEPT = 57364
-const EXISTS = 57365
-const FTOP = 57366
-const FALSE = 57367
-const FETCH = 57368
-const FIRST = 57369
-const FLOAT = 57370
-const FROM = 57371
-const FULL = 57372
-const GROUP = 57373
-const HAVING = 57374
-const INNER = 57375
-const INT = 57376
-const INTERSECT = 57377
-const IS = 57378
-const JOIN = 57379
-const NATURAL = 57380
-const NEXT = 57381
-const NOT = 57382
-const NULL = 57383
-const OFFSET = 57384
-const ON = 57385
-const ONLY = 57386
-const OR = 57387
-const ORDER = 57388
-const OUTER = 57389
-const RIGHT = 57390
-const ROW = 57391
-const ROWS = 57392
-const SELECT = 57393
-const STRING = 57394
-const TOP = 57395
-const TIME = 57396
-const TRUE = 57397
-const UNION = 57398
-const WHERE = 57399
-const NOT_LA = 57400
-const AT = 57401
-const UMINUS = 57402
-const LEFT = 57403
+const PLACEHOLDER = 57350
+const LESS_EQUALS = 57351
+const GREATER_EQUALS = 57352
+const NOT_EQUALS = 57353
+const ALL = 57354
+const AND = 57355
+const AS = 57356
+const ASC = 57357
+const BETWEEN = 57358
+const BOOL = 57359
+const BY = 57360
+const CASE = 57361
+const CAST = 57362
+const CREATE = 57363
+const CROSS = 57364
+const DESC = 57365
+const DISTINCT = 57366
+const ELSE = 57367
+const END = 57368
+const EXCEPT = 57369
+const EXISTS = 57370
+const FTOP = 57371
+const FALSE = 57372
+const FETCH = 57373
+const FIRST = 57374
+const FLOAT = 57375
+const FROM = 57376
+const FULL = 57377
+const GROUP = 57378
+const HAVING = 57379
+const IN = 57380
+const INDEX = 57381
+const INNER = 57382
+const INSERT = 57383
+const INT = 57384
+const INTERSECT = 57385
+const INTERVAL = 57386
+const INTO = 57387
+const IS = 57388
+const JOIN = 57389
+const NATURAL = 57390
+const NEXT = 57391
+const NOT = 57392
+const NULL = 57393
+const OFFSET = 57394
+const ON = 57395
+const ONLY = 57396
+const OR = 57397
+const ORDER = 57398
+const OUTER = 57399
+const RERANK = 57400
+const RIGHT = 57401
+const ROW = 57402
+const ROWS = 57403
+const SELECT = 57404
+const STRING = 57405
+const TABLE = 57406
+const TOP = 57407
+const THEN = 57408
+const TIME = 57409
+const TRUE = 57410
+const UNION = 57411
+const VALUES = 57412
+const WHEN = 57413
+const WHERE = 57414
+const WITH = 57415
+const NOT_LA = 57416
+const AT = 57417
+const UMINUS = 57418
+const LEFT = 57419
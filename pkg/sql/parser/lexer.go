@@ -21,7 +21,7 @@ type lexer struct {
 	// token returned by Lex().
 	lastPos int
 
-	stmt *tree.Select
+	stmt tree.Statement
 
 	lastError error
 }
@@ -64,7 +64,7 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			nextID = l.tokens[l.lastPos+1].id
 		}
 		switch nextID {
-		case BETWEEN:
+		case BETWEEN, IN:
 			lval.id = NOT_LA
 		}
 	}
@@ -87,7 +87,7 @@ func (l *lexer) lastToken() sqlSymType {
 }
 
 // SetStmt is called from the parser when the statement is constructed.
-func (l *lexer) SetStmt(stmt *tree.Select) {
+func (l *lexer) SetStmt(stmt tree.Statement) {
 	l.stmt = stmt
 }
 
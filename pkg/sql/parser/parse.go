@@ -1,6 +1,10 @@
 package parser
 
-import "github.com/deepfabric/vectorsql/pkg/sql/tree"
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+)
 
 type Parser struct {
 	lexer      lexer
@@ -9,6 +13,19 @@ type Parser struct {
 }
 
 func Parse(sql string) (*tree.Select, error) {
+	n, err := ParseStatement(sql)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := n.(*tree.Select)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not a query", n)
+	}
+	return sel, nil
+}
+
+// ParseStatement parses a query or an insert statement.
+func ParseStatement(sql string) (tree.Statement, error) {
 	var p Parser
 
 	p.scanner.init(sql)
@@ -17,7 +34,7 @@ func Parse(sql string) (*tree.Select, error) {
 }
 
 // parse parses a statement from the given scanned tokens.
-func (p *Parser) parse(sql string, tokens []sqlSymType) (*tree.Select, error) {
+func (p *Parser) parse(sql string, tokens []sqlSymType) (tree.Statement, error) {
 	p.lexer.init(sql, tokens)
 	defer p.lexer.cleanup()
 	if p.parserImpl.Parse(&p.lexer) != 0 {
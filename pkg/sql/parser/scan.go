@@ -27,6 +27,7 @@ const eof = -1
 const errUnterminated = "unterminated string"
 const errInvalidUTF8 = "invalid UTF-8 byte sequence"
 const errInvalidHexNumeric = "invalid hexadecimal numeric literal"
+const errMixedPlaceholder = "placeholders '?' and '$n' cannot be mixed"
 const singleQuote = '\''
 const identQuote = '"'
 
@@ -34,6 +35,8 @@ const identQuote = '"'
 type scanner struct {
 	in            string
 	pos           int
+	np            int  // number of placeholders '?'
+	isD           bool // placeholders '$n' are used
 	bytesPrealloc []byte
 }
 
@@ -46,6 +49,8 @@ func makeScanner(str string) scanner {
 func (s *scanner) init(str string) {
 	s.in = str
 	s.pos = 0
+	s.np = 0
+	s.isD = false
 	// Preallocate some buffer space for identifiers etc.
 	s.bytesPrealloc = make([]byte, len(str))
 }
@@ -148,6 +153,21 @@ func (s *scanner) scan(lval *sqlSymType) {
 			return
 		}
 		return
+	case '$':
+		if lex.IsDigit(s.peek()) {
+			s.scanPlaceholder(lval)
+		}
+		return
+	case '?':
+		if s.isD {
+			lval.id = ERROR
+			lval.str = errMixedPlaceholder
+			return
+		}
+		lval.id = PLACEHOLDER
+		lval.union.val = &tree.Placeholder{Idx: s.np}
+		s.np++
+		return
 	case '/':
 		return
 	case '-':
@@ -427,6 +447,29 @@ func (s *scanner) scanNumber(lval *sqlSymType, ch int) {
 	}
 }
 
+// scanPlaceholder scans the placeholder '$n', n starts from 1.
+func (s *scanner) scanPlaceholder(lval *sqlSymType) {
+	start := s.pos
+	for lex.IsDigit(s.peek()) {
+		s.pos++
+	}
+	lval.str = s.in[start:s.pos]
+	if s.np > 0 {
+		lval.id = ERROR
+		lval.str = errMixedPlaceholder
+		return
+	}
+	n, err := strconv.Atoi(lval.str)
+	if err != nil || n < 1 {
+		lval.id = ERROR
+		lval.str = fmt.Sprintf("invalid placeholder '$%s'", lval.str)
+		return
+	}
+	s.isD = true
+	lval.id = PLACEHOLDER
+	lval.union.val = &tree.Placeholder{Idx: n - 1}
+}
+
 // scanString scans the content inside '...'. This is used for simple
 // string literals '...' but also e'....' and b'...'. For x'...', see
 // scanHexString().
@@ -35,14 +35,71 @@ func (u *sqlSymUnion) setNegative() *tree.Value {
 	return &tree.Value{value.NewInt(int64(value.MustBeInt(v.E)) * -1)}
 }
 
+func (u *sqlSymUnion) negative() *tree.Value {
+	switch v := u.val.(*tree.Value).E.(type) {
+	case *value.Float:
+		return &tree.Value{value.NewFloat(float64(*v) * -1)}
+	default:
+		return &tree.Value{value.NewInt(int64(value.MustBeInt(v)) * -1)}
+	}
+}
+
+func (u *sqlSymUnion) float32() float32 {
+	switch v := u.val.(*tree.Value).E.(type) {
+	case *value.Float:
+		return float32(*v)
+	default:
+		return float32(value.MustBeInt(v))
+	}
+}
+
+func (u *sqlSymUnion) float32s() []float32 {
+	return u.val.([]float32)
+}
+
 func (u *sqlSymUnion) valueStatement() *tree.Value {
 	return u.val.(*tree.Value)
 }
 
+func (u *sqlSymUnion) statement() tree.Statement {
+	return u.val.(tree.Statement)
+}
+
 func (u *sqlSymUnion) selectStatement() *tree.Select {
 	return u.val.(*tree.Select)
 }
 
+func (u *sqlSymUnion) insertStatement() *tree.Insert {
+	return u.val.(*tree.Insert)
+}
+
+func (u *sqlSymUnion) valuesList() []tree.ExprStatements {
+	return u.val.([]tree.ExprStatements)
+}
+
+func (u *sqlSymUnion) createTableStatement() *tree.CreateTable {
+	return u.val.(*tree.CreateTable)
+}
+
+func (u *sqlSymUnion) tableDef() tree.TableDef {
+	return u.val.(tree.TableDef)
+}
+
+func (u *sqlSymUnion) tableDefs() tree.TableDefs {
+	return u.val.(tree.TableDefs)
+}
+
+func (u *sqlSymUnion) option() *tree.Option {
+	return u.val.(*tree.Option)
+}
+
+func (u *sqlSymUnion) options() tree.Options {
+	if u.val == nil {
+		return nil
+	}
+	return u.val.(tree.Options)
+}
+
 func (u *sqlSymUnion) limitStatement() *tree.Limit {
 	if u.val == nil {
 		return nil
@@ -163,6 +220,21 @@ func (u *sqlSymUnion) exprStatement() tree.ExprStatement {
 	return u.val.(tree.ExprStatement)
 }
 
+func (u *sqlSymUnion) optExprStatement() tree.ExprStatement {
+	if u.val == nil {
+		return nil
+	}
+	return u.val.(tree.ExprStatement)
+}
+
+func (u *sqlSymUnion) when() *tree.When {
+	return u.val.(*tree.When)
+}
+
+func (u *sqlSymUnion) whens() []*tree.When {
+	return u.val.([]*tree.When)
+}
+
 func (u *sqlSymUnion) exprStatements() tree.ExprStatements {
 	return u.val.(tree.ExprStatements)
 }
@@ -178,7 +250,7 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 	return u.val.(*tree.AliasClause)
 }
 
-//line sql.y:231
+//line sql.y:306
 type sqlSymType struct {
 	yys   int
 	id    int32
@@ -192,60 +264,76 @@ const IDENT = 57346
 const ICONST = 57347
 const FCONST = 57348
 const SCONST = 57349
-const LESS_EQUALS = 57350
-const GREATER_EQUALS = 57351
-const NOT_EQUALS = 57352
-const ALL = 57353
-const AND = 57354
-const AS = 57355
-const ASC = 57356
-const BETWEEN = 57357
-const BOOL = 57358
-const BY = 57359
-const CAST = 57360
-const CROSS = 57361
-const DESC = 57362
-const DISTINCT = 57363
-const EXCEPT = 57364
-const EXISTS = 57365
-const FTOP = 57366
-const FALSE = 57367
-const FETCH = 57368
-const FIRST = 57369
-const FLOAT = 57370
-const FROM = 57371
-const FULL = 57372
-const GROUP = 57373
-const HAVING = 57374
-const INNER = 57375
-const INT = 57376
-const INTERSECT = 57377
-const IS = 57378
-const JOIN = 57379
-const NATURAL = 57380
-const NEXT = 57381
-const NOT = 57382
-const NULL = 57383
-const OFFSET = 57384
-const ON = 57385
-const ONLY = 57386
-const OR = 57387
-const ORDER = 57388
-const OUTER = 57389
-const RIGHT = 57390
-const ROW = 57391
-const ROWS = 57392
-const SELECT = 57393
-const STRING = 57394
-const TOP = 57395
-const TIME = 57396
-const TRUE = 57397
-const UNION = 57398
-const WHERE = 57399
-const NOT_LA = 57400
-const AT = 57401
-const UMINUS = 57402
-const LEFT = 57403
+const PLACEHOLDER = 57350
+const LESS_EQUALS = 57351
+const GREATER_EQUALS = 57352
+const NOT_EQUALS = 57353
+const ALL = 57354
+const AND = 57355
+const AS = 57356
+const ASC = 57357
+const BETWEEN = 57358
+const BOOL = 57359
+const BY = 57360
+const CASE = 57361
+const CAST = 57362
+const CREATE = 57363
+const CROSS = 57364
+const DESC = 57365
+const DISTINCT = 57366
+const ELSE = 57367
+const END = 57368
+const EXCEPT = 57369
+const EXISTS = 57370
+const FTOP = 57371
+const FALSE = 57372
+const FETCH = 57373
+const FIRST = 57374
+const FLOAT = 57375
+const FROM = 57376
+const FULL = 57377
+const GROUP = 57378
+const HAVING = 57379
+const IN = 57380
+const INDEX = 57381
+const INNER = 57382
+const INSERT = 57383
+const INT = 57384
+const INTERSECT = 57385
+const INTERVAL = 57386
+const INTO = 57387
+const IS = 57388
+const JOIN = 57389
+const NATURAL = 57390
+const NEXT = 57391
+const NOT = 57392
+const NULL = 57393
+const OFFSET = 57394
+const ON = 57395
+const ONLY = 57396
+const OR = 57397
+const ORDER = 57398
+const OUTER = 57399
+const RERANK = 57400
+const RIGHT = 57401
+const ROW = 57402
+const ROWS = 57403
+const SELECT = 57404
+const STRING = 57405
+const TABLE = 57406
+const TOP = 57407
+const THEN = 57408
+const TIME = 57409
+const TRUE = 57410
+const UNION = 57411
+const VALUES = 57412
+const WHEN = 57413
+const WHERE = 57414
+const WITH = 57415
+const NOT_LA = 57416
+const AT = 57417
+const UMINUS = 57418
+const LEFT = 57419
 
 var sqlToknames = [...]string{
 	"$end",
@@ -255,6 +343,7 @@ var sqlToknames = [...]string{
 	"ICONST",
 	"FCONST",
 	"SCONST",
+	"PLACEHOLDER",
 	"LESS_EQUALS",
 	"GREATER_EQUALS",
 	"NOT_EQUALS",
@@ -265,10 +354,14 @@ var sqlToknames = [...]string{
 	"BETWEEN",
 	"BOOL",
 	"BY",
+	"CASE",
 	"CAST",
+	"CREATE",
 	"CROSS",
 	"DESC",
 	"DISTINCT",
+	"ELSE",
+	"END",
 	"EXCEPT",
 	"EXISTS",
 	"FTOP",
@@ -280,9 +373,14 @@ var sqlToknames = [...]string{
 	"FULL",
 	"GROUP",
 	"HAVING",
+	"IN",
+	"INDEX",
 	"INNER",
+	"INSERT",
 	"INT",
 	"INTERSECT",
+	"INTERVAL",
+	"INTO",
 	"IS",
 	"JOIN",
 	"NATURAL",
@@ -295,16 +393,22 @@ var sqlToknames = [...]string{
 	"OR",
 	"ORDER",
 	"OUTER",
+	"RERANK",
 	"RIGHT",
 	"ROW",
 	"ROWS",
 	"SELECT",
 	"STRING",
+	"TABLE",
 	"TOP",
+	"THEN",
 	"TIME",
 	"TRUE",
 	"UNION",
+	"VALUES",
+	"WHEN",
 	"WHERE",
+	"WITH",
 	"NOT_LA",
 	"'+'",
 	"'-'",
@@ -324,232 +428,315 @@ var sqlToknames = [...]string{
 	"LEFT",
 	"','",
 }
+
 var sqlStatenames = [...]string{}
 
 const sqlEofCode = 1
 const sqlErrCode = 2
 const sqlInitialStackSize = 16
 
-//line sql.y:787
+//line sql.y:1025
 
 //line yacctab:1
-var sqlExca = [...]int{
+var sqlExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 4,
-	1, 5,
-	26, 5,
-	42, 5,
-	70, 5,
-	-2, 110,
-	-1, 60,
-	69, 140,
-	-2, 131,
+	-1, 6,
+	1, 31,
+	31, 31,
+	52, 31,
+	86, 31,
+	-2, 181,
+	-1, 70,
+	85, 211,
+	-2, 202,
 }
 
 const sqlPrivate = 57344
 
-const sqlLast = 423
-
-var sqlAct = [...]int{
-
-	46, 42, 179, 222, 201, 134, 154, 149, 5, 189,
-	20, 213, 3, 146, 88, 4, 212, 212, 98, 96,
-	73, 74, 25, 40, 4, 94, 196, 14, 53, 54,
-	55, 197, 204, 192, 26, 243, 186, 211, 78, 77,
-	124, 64, 123, 60, 13, 119, 47, 104, 57, 24,
-	140, 120, 121, 13, 65, 76, 107, 108, 109, 151,
-	11, 122, 91, 45, 58, 95, 24, 125, 68, 237,
-	79, 49, 10, 131, 135, 71, 13, 208, 56, 48,
-	89, 10, 50, 51, 43, 69, 103, 190, 191, 139,
-	187, 188, 59, 87, 105, 106, 107, 108, 109, 38,
-	185, 86, 159, 160, 10, 155, 163, 164, 165, 166,
-	167, 168, 169, 170, 171, 172, 173, 174, 158, 152,
-	14, 138, 24, 157, 13, 180, 181, 13, 13, 13,
-	13, 136, 176, 13, 4, 156, 85, 14, 127, 97,
-	13, 141, 198, 103, 142, 143, 144, 145, 19, 200,
-	148, 132, 10, 203, 126, 10, 10, 10, 10, 199,
-	30, 10, 101, 13, 101, 207, 205, 206, 10, 202,
-	17, 34, 101, 102, 37, 223, 210, 18, 32, 33,
-	162, 161, 70, 118, 12, 119, 102, 216, 102, 36,
-	30, 10, 101, 215, 135, 100, 102, 100, 219, 24,
-	24, 34, 9, 224, 37, 100, 28, 72, 32, 33,
-	227, 225, 226, 155, 229, 35, 102, 70, 221, 36,
-	30, 175, 238, 29, 90, 239, 180, 240, 182, 242,
-	241, 34, 101, 71, 37, 101, 28, 194, 32, 33,
-	23, 220, 129, 195, 147, 35, 14, 14, 13, 36,
-	14, 53, 54, 55, 130, 22, 102, 27, 218, 102,
-	92, 93, 217, 75, 64, 100, 52, 62, 100, 47,
-	61, 57, 228, 184, 230, 35, 10, 14, 53, 54,
-	55, 30, 231, 81, 29, 21, 45, 58, 44, 150,
-	177, 64, 34, 82, 41, 37, 47, 28, 57, 32,
-	33, 56, 128, 14, 183, 50, 51, 209, 31, 66,
-	36, 101, 99, 45, 58, 59, 178, 80, 27, 105,
-	106, 107, 108, 109, 14, 53, 54, 55, 56, 67,
-	153, 137, 50, 51, 43, 102, 35, 133, 64, 101,
-	214, 15, 59, 47, 100, 57, 83, 84, 14, 53,
-	54, 55, 16, 101, 105, 106, 107, 108, 109, 39,
-	45, 58, 64, 102, 113, 114, 115, 63, 193, 57,
-	233, 116, 100, 8, 7, 56, 6, 102, 2, 50,
-	51, 1, 235, 0, 0, 58, 100, 0, 232, 59,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 56,
-	0, 0, 0, 50, 51, 0, 236, 0, 234, 0,
-	0, 0, 0, 59, 117, 105, 106, 107, 108, 109,
-	110, 111, 112,
-}
-var sqlPact = [...]int{
-
-	133, -1000, -1000, -1000, 124, 242, -1000, -1000, -1000, 133,
-	-37, 262, 273, -13, -1000, 191, -1000, 190, 320, 320,
-	-1000, -1000, 243, -30, -1000, -32, 243, 272, 272, 272,
-	99, 64, 133, 43, 15, 15, 15, -1000, -10, 23,
-	-1000, -1000, 299, -1000, -1000, 320, 356, -24, -1000, -37,
-	344, 344, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 320,
-	-13, -1000, -1000, -27, -29, 320, -1000, -1000, 33, 156,
-	215, 320, 320, 341, 341, -30, -1000, 243, 242, -17,
-	133, -1000, -1000, 133, 133, 133, 133, 201, -1000, 133,
-	-1000, -1000, -1000, -1000, 2, 23, 116, -10, -1000, 243,
-	320, 320, 140, -1000, 137, 344, 344, 344, 344, 344,
-	344, 344, 344, 344, 344, 344, 344, 206, -1000, 133,
-	-5, -5, 220, 246, 320, 160, -1000, -1000, 31, -1000,
-	-1000, 341, 38, -42, -1000, 223, -1000, -44, -1000, -1000,
-	320, 171, 141, 171, -1000, 201, -1000, 320, -1000, 138,
-	-1000, 320, -1000, -43, -1000, 242, 242, 2, -1000, 180,
-	137, -1000, 36, -5, -5, -1000, -1000, -1000, 35, 35,
-	35, 35, 35, 35, 295, 344, -33, -1000, -1000, -59,
-	341, 327, -1000, 38, -1000, 320, -1000, 257, 253, -1000,
-	-1000, -1000, 320, -1000, -1000, -1000, -1000, 243, 150, -1000,
-	341, 143, 186, 341, 116, -1000, -1000, 138, -1000, 344,
-	260, -1000, 320, -1000, 354, 25, 152, -1000, -1000, -1000,
-	-1000, -1000, -1000, 320, 320, -1000, 143, 35, 344, 341,
-	-35, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 341,
-	-58, -1000, 35, -1000,
-}
-var sqlPgo = [...]int{
-
-	0, 381, 378, 12, 14, 376, 374, 60, 373, 135,
-	368, 43, 367, 240, 18, 8, 71, 359, 55, 352,
-	341, 337, 331, 25, 330, 2, 99, 4, 329, 309,
-	317, 224, 13, 308, 68, 85, 304, 9, 302, 289,
-	7, 1, 0, 288, 79, 3, 285, 10, 5, 6,
-	23, 282, 274, 273, 270, 267, 266,
-}
-var sqlR1 = [...]int{
-
-	0, 1, 2, 3, 20, 20, 19, 19, 19, 21,
-	21, 48, 10, 10, 10, 29, 29, 28, 28, 28,
-	28, 34, 35, 35, 36, 36, 36, 37, 37, 38,
-	38, 4, 4, 4, 4, 4, 8, 8, 17, 26,
-	26, 50, 50, 50, 50, 23, 23, 24, 24, 40,
-	40, 39, 27, 27, 45, 45, 25, 25, 41, 41,
-	41, 41, 41, 41, 41, 42, 42, 42, 42, 42,
-	42, 42, 42, 42, 42, 43, 43, 43, 43, 43,
-	43, 43, 43, 43, 44, 44, 44, 44, 44, 44,
-	44, 53, 53, 53, 56, 56, 54, 54, 55, 52,
-	51, 51, 51, 51, 51, 46, 46, 47, 47, 9,
-	7, 6, 6, 6, 30, 30, 30, 5, 5, 5,
-	5, 32, 33, 33, 33, 33, 31, 31, 49, 49,
-	15, 16, 16, 16, 16, 18, 18, 22, 22, 11,
-	12, 14, 13,
-}
-var sqlR2 = [...]int{
-
-	0, 1, 1, 3, 1, 0, 3, 2, 2, 1,
-	3, 2, 1, 1, 0, 1, 0, 2, 2, 1,
-	1, 5, 2, 3, 1, 3, 0, 1, 1, 1,
-	1, 2, 1, 1, 1, 4, 6, 7, 1, 1,
-	3, 1, 2, 3, 1, 2, 0, 1, 3, 1,
-	0, 2, 3, 0, 2, 0, 1, 3, 1, 2,
-	3, 3, 3, 4, 1, 1, 1, 2, 2, 3,
-	3, 3, 3, 3, 1, 3, 3, 3, 3, 3,
-	3, 5, 6, 2, 1, 1, 1, 1, 1, 1,
-	3, 1, 2, 2, 1, 1, 3, 4, 6, 1,
-	1, 1, 1, 1, 1, 3, 2, 1, 0, 3,
-	1, 4, 4, 4, 1, 1, 0, 4, 5, 4,
-	4, 2, 2, 2, 2, 1, 1, 0, 2, 2,
-	1, 1, 4, 3, 6, 3, 0, 1, 3, 1,
-	1, 1, 1,
-}
-var sqlChk = [...]int{
-
-	-1000, -1, -2, -3, -4, -15, -5, -6, -8, 69,
-	-16, -7, 51, -11, 4, -20, -19, 46, 53, 24,
-	-47, -46, 13, -13, -11, -3, 71, 56, 35, 22,
-	19, -33, 37, 38, 30, 74, 48, 33, -26, -17,
-	-50, 21, -41, 61, -43, 40, -42, 23, -44, -16,
-	59, 60, -56, 5, 6, 7, 55, 25, 41, 69,
-	-11, -54, -55, -12, 18, 67, -29, -28, -34, -35,
-	26, 42, 17, -41, -41, -13, -18, 69, 70, -11,
-	-30, 11, 21, -30, -30, 37, 37, -7, -4, 37,
-	-31, 47, -31, -31, -23, 75, 29, -26, -14, 13,
-	45, 12, 36, -11, -41, 59, 60, 61, 62, 63,
-	64, 65, 66, 8, 9, 10, 15, 58, -9, 69,
-	-42, -42, -41, 69, 69, -41, -35, -34, -38, 27,
-	39, -41, -44, -21, -48, -41, -18, -22, -11, -47,
-	67, -7, -7, -7, -7, -7, -32, 43, -7, -40,
-	-39, 57, -50, -24, -49, -15, -9, -23, -14, -41,
-	-41, 41, 40, -42, -42, -42, -42, -42, -42, -42,
-	-42, -42, -42, -42, -42, 15, -3, 70, 70, -25,
-	-41, -41, 68, -36, -53, 69, 5, 59, 60, -37,
-	49, 50, 75, -10, 14, 20, 70, 75, -41, -32,
-	-41, -27, 31, -41, 75, -47, -47, -40, 41, 12,
-	-42, 70, 75, 70, 13, -37, -41, 5, 5, -48,
-	-11, 68, -45, 32, 17, -49, -27, -42, 12, -41,
-	-52, -51, 34, 16, 54, 28, 52, 44, 70, -41,
-	-25, -45, -42, 70,
-}
-var sqlDef = [...]int{
-
-	0, -2, 1, 2, -2, 108, 32, 33, 34, 0,
-	130, 0, 0, 131, 139, 16, 4, 0, 0, 0,
-	31, 107, 0, 136, 142, 0, 0, 116, 116, 116,
-	0, 0, 0, 0, 127, 127, 127, 125, 46, 0,
-	39, 38, 41, 44, 58, 0, 64, 0, 65, 66,
-	0, 0, 74, 84, 85, 86, 87, 88, 89, 0,
-	-2, 94, 95, 0, 0, 0, 3, 15, 19, 20,
-	0, 0, 0, 7, 8, 136, 106, 0, 108, 133,
-	0, 114, 115, 0, 0, 0, 0, 0, 110, 0,
-	122, 126, 123, 124, 50, 0, 0, 46, 42, 0,
-	0, 0, 0, 141, 59, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 83, 0,
-	67, 68, 0, 0, 0, 0, 17, 18, 26, 29,
-	30, 22, 65, 6, 9, 14, 105, 0, 137, 35,
-	0, 111, 112, 113, 117, 0, 119, 0, 120, 53,
-	49, 0, 40, 45, 47, 108, 108, 50, 43, 60,
-	61, 62, 0, 69, 70, 71, 72, 73, 75, 76,
-	77, 78, 79, 80, 0, 0, 0, 90, 96, 0,
-	56, 0, 132, 0, 24, 0, 91, 0, 0, 23,
-	27, 28, 0, 11, 12, 13, 135, 0, 0, 118,
-	121, 55, 0, 51, 0, 128, 129, 53, 63, 0,
-	0, 109, 0, 97, 0, 0, 0, 92, 93, 10,
-	138, 134, 36, 0, 0, 48, 55, 81, 0, 57,
-	0, 99, 100, 101, 102, 103, 104, 21, 25, 54,
-	52, 37, 82, 98,
-}
-var sqlTok1 = [...]int{
+const sqlLast = 650
+
+var sqlAct = [...]int16{
+	70, 17, 137, 308, 370, 226, 247, 273, 314, 171,
+	30, 252, 167, 160, 17, 12, 52, 260, 272, 158,
+	101, 6, 9, 188, 77, 17, 17, 159, 235, 30,
+	48, 26, 223, 92, 6, 183, 84, 85, 19, 17,
+	180, 107, 111, 302, 46, 239, 374, 86, 87, 116,
+	190, 375, 368, 240, 290, 367, 356, 290, 36, 48,
+	256, 323, 344, 35, 322, 117, 263, 290, 109, 323,
+	306, 40, 304, 134, 135, 307, 43, 256, 218, 34,
+	136, 238, 89, 38, 39, 219, 32, 147, 373, 181,
+	150, 172, 30, 36, 17, 42, 156, 17, 17, 17,
+	17, 16, 168, 17, 132, 33, 40, 240, 18, 165,
+	17, 43, 15, 116, 34, 291, 255, 372, 38, 39,
+	290, 256, 48, 173, 166, 108, 41, 193, 194, 359,
+	42, 292, 189, 18, 17, 197, 198, 199, 200, 201,
+	202, 203, 204, 205, 206, 207, 208, 282, 91, 364,
+	114, 100, 191, 186, 6, 192, 232, 248, 350, 343,
+	36, 41, 228, 229, 311, 138, 16, 172, 233, 271,
+	253, 170, 212, 40, 90, 244, 249, 169, 43, 55,
+	14, 149, 211, 115, 148, 38, 39, 133, 257, 13,
+	30, 30, 113, 14, 174, 259, 76, 42, 114, 262,
+	18, 376, 79, 348, 14, 14, 175, 250, 54, 176,
+	177, 178, 179, 17, 133, 182, 185, 8, 14, 80,
+	258, 264, 265, 340, 143, 144, 269, 266, 41, 224,
+	25, 115, 104, 6, 339, 139, 140, 7, 289, 16,
+	113, 120, 121, 122, 138, 234, 379, 345, 295, 236,
+	237, 288, 296, 297, 303, 287, 82, 312, 16, 309,
+	294, 270, 228, 114, 17, 300, 301, 114, 298, 309,
+	365, 214, 17, 14, 114, 305, 14, 14, 14, 14,
+	138, 13, 14, 152, 318, 319, 189, 317, 267, 14,
+	321, 157, 6, 283, 284, 145, 115, 224, 102, 151,
+	115, 44, 329, 142, 331, 113, 99, 115, 253, 113,
+	335, 81, 172, 14, 139, 140, 113, 346, 310, 349,
+	168, 351, 353, 196, 195, 114, 337, 354, 310, 352,
+	228, 357, 82, 114, 313, 334, 23, 355, 230, 118,
+	119, 120, 121, 122, 358, 98, 115, 110, 24, 362,
+	139, 140, 363, 103, 366, 360, 338, 228, 115, 209,
+	336, 315, 361, 21, 285, 371, 115, 18, 59, 60,
+	61, 62, 22, 261, 18, 113, 371, 81, 341, 380,
+	377, 210, 73, 75, 328, 93, 18, 59, 60, 61,
+	62, 53, 14, 64, 114, 105, 106, 342, 36, 29,
+	154, 73, 75, 35, 316, 246, 47, 68, 320, 254,
+	53, 40, 64, 51, 65, 245, 43, 155, 94, 34,
+	96, 97, 83, 38, 39, 268, 68, 115, 88, 217,
+	95, 63, 51, 65, 18, 42, 113, 216, 56, 57,
+	227, 213, 3, 14, 28, 33, 69, 330, 66, 225,
+	63, 14, 326, 327, 18, 31, 215, 56, 57, 49,
+	18, 59, 60, 61, 62, 69, 41, 66, 114, 293,
+	118, 119, 120, 121, 122, 73, 75, 222, 114, 18,
+	59, 60, 61, 62, 53, 286, 64, 118, 119, 120,
+	121, 122, 324, 325, 73, 75, 220, 221, 146, 67,
+	68, 115, 58, 53, 72, 64, 51, 65, 71, 141,
+	113, 115, 18, 59, 60, 61, 62, 332, 333, 68,
+	113, 162, 27, 161, 63, 51, 65, 73, 75, 50,
+	184, 56, 57, 49, 153, 231, 37, 114, 64, 69,
+	78, 66, 187, 63, 20, 18, 274, 275, 276, 277,
+	56, 57, 68, 274, 275, 276, 277, 45, 69, 65,
+	66, 74, 241, 114, 11, 126, 127, 128, 10, 378,
+	115, 279, 129, 369, 347, 251, 63, 5, 279, 113,
+	299, 164, 4, 56, 57, 114, 2, 242, 1, 0,
+	0, 69, 280, 66, 131, 243, 115, 0, 18, 280,
+	0, 0, 0, 16, 0, 113, 163, 114, 112, 278,
+	0, 0, 0, 0, 0, 0, 278, 281, 115, 0,
+	0, 0, 0, 0, 281, 0, 13, 113, 0, 0,
+	130, 118, 119, 120, 121, 122, 123, 124, 125, 0,
+	115, 0, 0, 0, 0, 0, 0, 0, 0, 113,
+}
+
+var sqlPact = [...]int16{
+	196, -32768, -32768, -32768, -32768, -32768, 307, 303, 166, 430,
+	-32768, -32768, -32768, 104, -1, 376, 382, 113, -32768, 280,
+	-32768, 404, 475, 475, 450, 450, -32768, -32768, 450, 89,
+	-32768, 62, 450, 406, 406, 406, 298, 259, 104, 251,
+	175, 175, 175, -32768, 34, 456, -32768, -32768, 594, -32768,
+	-32768, 475, 556, 102, -32768, -1, 508, 508, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 475, -32768, 275, 219,
+	113, -32768, -32768, 475, 99, 96, 475, -32768, -32768, 204,
+	346, 368, 475, 475, 465, 550, 39, 92, 89, -32768,
+	450, 430, 111, 104, -32768, -32768, 104, 104, 104, 104,
+	36, -32768, 104, -32768, -32768, -32768, -32768, 144, 456, 129,
+	34, -32768, 450, 475, 475, 273, -32768, 300, 508, 508,
+	508, 508, 508, 508, 508, 508, 508, 508, 508, 508,
+	343, 87, -32768, 104, 164, 164, 185, 452, -32768, 432,
+	424, -6, -32768, -32768, -32768, 491, 158, 261, 363, 475,
+	254, -32768, -32768, 160, -32768, -32768, 261, 189, 16, -32768,
+	572, 475, 397, 387, -32768, 72, 450, -32768, 307, 370,
+	-32768, 30, -32768, -32768, 475, 71, 138, 71, -32768, 36,
+	-32768, 475, -32768, 337, -32768, 475, -32768, -25, -32768, 430,
+	430, 144, -32768, 312, 300, -32768, 237, 164, 164, -32768,
+	-32768, -32768, 264, 264, 264, 264, 264, 264, 412, 508,
+	84, -32768, 541, 61, -32768, -32768, -32768, -32768, -32768, 288,
+	-32768, -32768, 226, -32768, 475, -32768, 29, 45, 261, 455,
+	-32768, 189, -32768, -32768, 475, -32768, -32768, -32768, 475, 475,
+	475, -32768, -32768, -32768, 524, 475, 475, -48, 475, -14,
+	280, -16, -32768, 255, 79, -32768, 450, 250, -32768, 261,
+	324, 386, 261, 129, -32768, -32768, 337, -32768, 508, 395,
+	-32768, 541, -22, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 487, -32768, -32768, -32768, 447, 358, -32768, 475, 381,
+	475, -32768, -32768, 293, 180, 137, 320, 261, -32768, 379,
+	-38, -38, 74, -24, 177, -32768, 130, 370, -32768, 73,
+	-32768, 450, -32768, -32768, -32768, 475, 475, -32768, 324, 264,
+	508, -30, -32768, 548, -32768, -32768, -32768, -32768, -32768, 261,
+	475, 261, 43, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 475, 475, 475, -32768, 72, -32768, -32768, 64, -32768,
+	265, -31, 261, -37, -32768, 264, -32768, -32768, 261, -32768,
+	261, -38, -34, -48, 450, 31, 2, -32768, -32768, -40,
+	-32768, 119, -32768, -32768, -32768, 450, 239, -32768, -32768, -32768,
+	-32768,
+}
+
+var sqlPgo = [...]int16{
+	0, 588, 586, 441, 582, 581, 12, 6, 577, 11,
+	575, 574, 4, 573, 569, 3, 20, 568, 564, 112,
+	15, 50, 562, 0, 561, 399, 42, 22, 179, 557,
+	82, 544, 38, 19, 9, 41, 542, 5, 18, 7,
+	301, 17, 540, 24, 385, 353, 40, 536, 202, 219,
+	535, 28, 534, 530, 35, 13, 16, 529, 208, 8,
+	522, 31, 27, 23, 44, 518, 517, 2, 509, 508,
+	504, 502, 499, 498, 485, 32, 477,
+}
+
+var sqlR1 = [...]int8{
+	0, 1, 2, 2, 2, 8, 10, 10, 9, 9,
+	15, 15, 15, 15, 11, 11, 13, 13, 12, 14,
+	14, 4, 5, 5, 5, 5, 6, 7, 7, 3,
+	32, 32, 31, 31, 31, 31, 31, 31, 31, 31,
+	31, 31, 33, 33, 62, 22, 22, 22, 43, 43,
+	42, 42, 42, 42, 48, 49, 49, 50, 50, 50,
+	50, 51, 51, 52, 52, 16, 16, 16, 16, 16,
+	20, 20, 29, 40, 40, 64, 64, 64, 64, 35,
+	35, 36, 36, 54, 54, 53, 41, 41, 59, 59,
+	37, 37, 55, 55, 55, 55, 55, 55, 55, 56,
+	56, 56, 56, 56, 56, 56, 56, 56, 56, 57,
+	57, 57, 57, 57, 57, 57, 57, 57, 57, 57,
+	57, 57, 58, 58, 58, 58, 58, 58, 58, 58,
+	58, 58, 58, 58, 68, 68, 68, 68, 68, 68,
+	68, 68, 72, 73, 73, 76, 76, 75, 74, 74,
+	38, 38, 39, 39, 39, 39, 39, 39, 39, 39,
+	39, 67, 67, 67, 71, 71, 69, 69, 69, 70,
+	66, 65, 65, 65, 65, 65, 60, 60, 61, 61,
+	21, 19, 18, 18, 18, 44, 44, 44, 17, 17,
+	17, 17, 46, 47, 47, 47, 47, 45, 45, 63,
+	63, 27, 28, 28, 28, 28, 30, 30, 34, 34,
+	23, 24, 26, 25,
+}
+
+var sqlR2 = [...]int8{
+	0, 1, 1, 1, 1, 7, 1, 3, 2, 4,
+	1, 1, 4, 4, 4, 0, 1, 3, 3, 1,
+	1, 4, 2, 5, 1, 4, 3, 3, 5, 3,
+	1, 0, 3, 2, 4, 2, 5, 7, 5, 5,
+	7, 5, 1, 3, 2, 1, 1, 0, 1, 0,
+	2, 2, 1, 1, 5, 2, 3, 1, 1, 3,
+	0, 1, 1, 1, 1, 2, 1, 1, 1, 4,
+	6, 7, 1, 1, 3, 1, 2, 3, 1, 2,
+	0, 1, 3, 1, 0, 2, 3, 0, 2, 0,
+	1, 3, 1, 2, 3, 3, 3, 4, 1, 1,
+	1, 2, 2, 3, 3, 3, 3, 3, 1, 3,
+	3, 3, 3, 3, 3, 5, 6, 3, 4, 5,
+	6, 2, 1, 1, 1, 1, 1, 1, 1, 3,
+	1, 3, 3, 2, 1, 1, 2, 2, 3, 3,
+	4, 4, 5, 1, 0, 1, 2, 4, 2, 0,
+	1, 3, 1, 1, 1, 1, 1, 1, 1, 2,
+	2, 1, 2, 2, 1, 1, 3, 4, 4, 6,
+	1, 1, 1, 1, 1, 1, 3, 2, 1, 0,
+	3, 1, 4, 4, 4, 1, 1, 0, 4, 5,
+	4, 4, 2, 2, 2, 2, 1, 1, 0, 2,
+	2, 1, 1, 4, 3, 6, 3, 0, 1, 3,
+	1, 1, 1, 1,
+}
+
+var sqlChk = [...]int16{
+	-32768, -1, -2, -3, -4, -8, -16, 41, 21, -27,
+	-17, -18, -20, 85, -28, -19, 62, -23, 4, -32,
+	-31, 56, 65, 29, 45, 64, -61, -60, 14, -25,
+	-23, -3, 87, 69, 43, 27, 22, -47, 47, 48,
+	35, 90, 59, 40, -40, -29, -64, 24, -55, 77,
+	-57, 50, -56, 28, -58, -28, 75, 76, -71, 5,
+	6, 7, 8, 68, 30, 51, 85, -72, 44, 83,
+	-23, -69, -70, 19, -24, 20, 83, -43, -42, -48,
+	-49, 31, 52, 18, -55, -55, -27, -27, -25, -30,
+	85, 86, -23, -44, 12, 24, -44, -44, 47, 47,
+	-19, -16, 47, -45, 57, -45, -45, -35, 91, 34,
+	-40, -26, 14, 55, 13, 46, -23, -55, 75, 76,
+	77, 78, 79, 80, 81, 82, 9, 10, 11, 16,
+	74, 38, -21, 85, -56, -56, -55, -67, 5, 75,
+	76, -68, 84, 5, 6, 76, -73, -55, 85, 85,
+	-55, -49, -48, -52, 32, 49, -55, -58, -33, -62,
+	-55, 58, 56, 56, -5, 70, 85, -6, -20, 85,
+	-30, -34, -23, -61, 83, -19, -19, -19, -19, -19,
+	-46, 53, -19, -54, -53, 72, -64, -36, -63, -27,
+	-21, -35, -26, -55, -55, 51, 50, -56, -56, -56,
+	-56, -56, -56, -56, -56, -56, -56, -56, -56, 16,
+	38, -21, 85, -3, 86, 4, 5, 5, 84, 91,
+	5, 6, -76, -75, 71, 86, -37, 77, -55, -55,
+	84, -50, -67, 8, 85, -51, 60, 61, 65, 29,
+	91, -22, 15, 23, -55, 18, 18, -7, 85, -34,
+	-32, -10, -9, -23, 39, 86, 91, -55, -46, -55,
+	-41, 36, -55, 91, -61, -61, -54, 51, 13, -56,
+	-21, 85, -38, -39, 5, 6, 7, 8, 68, 30,
+	51, 76, 86, 5, 6, 76, -74, -75, 25, -55,
+	91, 86, 86, 14, -51, -55, -55, -55, -62, 56,
+	-33, -33, 91, -37, 86, -43, 86, 91, -15, 4,
+	63, 85, -23, 84, -59, 37, 18, -63, -41, -56,
+	13, -38, 86, 91, 5, 6, 5, 6, 26, -55,
+	66, -55, -66, -65, 42, 17, 67, 33, 63, 54,
+	86, 58, 18, 85, 86, 70, -6, -11, 73, -9,
+	85, -34, -55, -37, -59, -56, 86, -39, -55, 86,
+	-55, -33, -37, -7, 85, 5, -15, 86, 86, -13,
+	-12, -23, 86, 86, 86, 91, 82, -12, -14, 7,
+	-67,
+}
+
+var sqlDef = [...]int16{
+	0, -2, 1, 2, 3, 4, -2, 0, 0, 179,
+	66, 67, 68, 0, 201, 0, 0, 202, 210, 49,
+	30, 0, 0, 0, 0, 0, 65, 178, 0, 207,
+	213, 0, 0, 187, 187, 187, 0, 0, 0, 0,
+	198, 198, 198, 196, 80, 0, 73, 72, 75, 78,
+	92, 0, 98, 0, 99, 100, 0, 0, 108, 122,
+	123, 124, 125, 126, 127, 128, 0, 130, 0, 0,
+	-2, 164, 165, 144, 0, 0, 0, 29, 48, 52,
+	53, 0, 0, 0, 33, 35, 0, 0, 207, 177,
+	0, 179, 204, 0, 185, 186, 0, 0, 0, 0,
+	0, 181, 0, 193, 197, 194, 195, 84, 0, 0,
+	80, 76, 0, 0, 0, 0, 212, 93, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 121, 0, 101, 102, 0, 0, 161, 0,
+	0, 0, 133, 134, 135, 0, 0, 143, 0, 0,
+	0, 50, 51, 60, 63, 64, 55, 99, 32, 42,
+	47, 0, 0, 0, 21, 0, 0, 24, 31, 0,
+	176, 0, 208, 69, 0, 182, 183, 184, 188, 0,
+	190, 0, 191, 87, 83, 0, 74, 79, 81, 179,
+	179, 84, 77, 94, 95, 96, 0, 103, 104, 105,
+	106, 107, 109, 110, 111, 112, 113, 114, 0, 0,
+	0, 117, 0, 0, 129, 131, 162, 163, 132, 0,
+	136, 137, 149, 145, 0, 166, 0, 0, 90, 0,
+	203, 0, 57, 58, 0, 56, 61, 62, 0, 0,
+	0, 44, 45, 46, 34, 0, 0, 22, 0, 0,
+	49, 0, 6, 0, 0, 206, 0, 0, 189, 192,
+	89, 0, 85, 0, 199, 200, 87, 97, 0, 0,
+	118, 0, 0, 150, 152, 153, 154, 155, 156, 157,
+	158, 0, 180, 138, 139, 0, 0, 146, 0, 0,
+	0, 167, 168, 0, 0, 0, 39, 41, 43, 0,
+	36, 38, 0, 0, 0, 26, 15, 0, 8, 10,
+	11, 0, 209, 205, 70, 0, 0, 82, 89, 115,
+	0, 0, 119, 0, 159, 160, 140, 141, 142, 148,
+	0, 91, 0, 170, 171, 172, 173, 174, 175, 54,
+	59, 0, 0, 0, 27, 0, 25, 5, 0, 7,
+	0, 0, 88, 86, 71, 116, 120, 151, 147, 169,
+	40, 37, 0, 23, 0, 0, 0, 9, 28, 0,
+	16, 0, 12, 13, 14, 0, 0, 17, 18, 19,
+	20,
+}
 
+var sqlTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 63, 3, 3,
-	69, 70, 61, 59, 75, 60, 71, 62, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 79, 3, 3,
+	85, 86, 77, 75, 91, 76, 87, 78, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	64, 66, 65, 3, 3, 3, 3, 3, 3, 3,
+	80, 82, 81, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 67, 3, 68,
+	3, 83, 3, 84,
 }
-var sqlTok2 = [...]int{
 
+var sqlTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
-	52, 53, 54, 55, 56, 57, 58, 72, 73, 74,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73, 74, 88, 89, 90,
 }
-var sqlTok3 = [...]int{
+
+var sqlTok3 = [...]int8{
 	0,
 }
 
@@ -592,7 +779,7 @@ func sqlNewParser() sqlParser {
 	return &sqlParserImpl{}
 }
 
-const sqlFlag = -1000
+const sqlFlag = -32768
 
 func sqlTokname(c int) string {
 	if c >= 1 && c-1 < len(sqlToknames) {
@@ -631,9 +818,9 @@ func sqlErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := sqlPact[state]
+	base := int(sqlPact[state])
 	for tok := TOKSTART; tok-1 < len(sqlToknames); tok++ {
-		if n := base + tok; n >= 0 && n < sqlLast && sqlChk[sqlAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < sqlLast && int(sqlChk[int(sqlAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -643,13 +830,13 @@ func sqlErrorMessage(state, lookAhead int) string {
 
 	if sqlDef[state] == -2 {
 		i := 0
-		for sqlExca[i] != -1 || sqlExca[i+1] != state {
+		for sqlExca[i] != -1 || int(sqlExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; sqlExca[i] >= 0; i += 2 {
-			tok := sqlExca[i]
+			tok := int(sqlExca[i])
 			if tok < TOKSTART || sqlExca[i+1] == 0 {
 				continue
 			}
@@ -680,30 +867,30 @@ func sqllex1(lex sqlLexer, lval *sqlSymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = sqlTok1[0]
+		token = int(sqlTok1[0])
 		goto out
 	}
 	if char < len(sqlTok1) {
-		token = sqlTok1[char]
+		token = int(sqlTok1[char])
 		goto out
 	}
 	if char >= sqlPrivate {
 		if char < sqlPrivate+len(sqlTok2) {
-			token = sqlTok2[char-sqlPrivate]
+			token = int(sqlTok2[char-sqlPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(sqlTok3); i += 2 {
-		token = sqlTok3[i+0]
+		token = int(sqlTok3[i+0])
 		if token == char {
-			token = sqlTok3[i+1]
+			token = int(sqlTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = sqlTok2[1] /* unknown char */
+		token = int(sqlTok2[1]) /* unknown char */
 	}
 	if sqlDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", sqlTokname(token), uint(char))
@@ -758,7 +945,7 @@ sqlstack:
 	sqlS[sqlp].yys = sqlstate
 
 sqlnewstate:
-	sqln = sqlPact[sqlstate]
+	sqln = int(sqlPact[sqlstate])
 	if sqln <= sqlFlag {
 		goto sqldefault /* simple state */
 	}
@@ -769,8 +956,8 @@ sqlnewstate:
 	if sqln < 0 || sqln >= sqlLast {
 		goto sqldefault
 	}
-	sqln = sqlAct[sqln]
-	if sqlChk[sqln] == sqltoken { /* valid shift */
+	sqln = int(sqlAct[sqln])
+	if int(sqlChk[sqln]) == sqltoken { /* valid shift */
 		sqlrcvr.char = -1
 		sqltoken = -1
 		sqlVAL = sqlrcvr.lval
@@ -783,7 +970,7 @@ sqlnewstate:
 
 sqldefault:
 	/* default state action */
-	sqln = sqlDef[sqlstate]
+	sqln = int(sqlDef[sqlstate])
 	if sqln == -2 {
 		if sqlrcvr.char < 0 {
 			sqlrcvr.char, sqltoken = sqllex1(sqllex, &sqlrcvr.lval)
@@ -792,18 +979,18 @@ sqldefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if sqlExca[xi+0] == -1 && sqlExca[xi+1] == sqlstate {
+			if sqlExca[xi+0] == -1 && int(sqlExca[xi+1]) == sqlstate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			sqln = sqlExca[xi+0]
+			sqln = int(sqlExca[xi+0])
 			if sqln < 0 || sqln == sqltoken {
 				break
 			}
 		}
-		sqln = sqlExca[xi+1]
+		sqln = int(sqlExca[xi+1])
 		if sqln < 0 {
 			goto ret0
 		}
@@ -825,10 +1012,10 @@ sqldefault:
 
 			/* find a state where "error" is a legal shift action */
 			for sqlp >= 0 {
-				sqln = sqlPact[sqlS[sqlp].yys] + sqlErrCode
+				sqln = int(sqlPact[sqlS[sqlp].yys]) + sqlErrCode
 				if sqln >= 0 && sqln < sqlLast {
-					sqlstate = sqlAct[sqln] /* simulate a shift of "error" */
-					if sqlChk[sqlstate] == sqlErrCode {
+					sqlstate = int(sqlAct[sqln]) /* simulate a shift of "error" */
+					if int(sqlChk[sqlstate]) == sqlErrCode {
 						goto sqlstack
 					}
 				}
@@ -864,7 +1051,7 @@ sqldefault:
 	sqlpt := sqlp
 	_ = sqlpt // guard against "declared and not used"
 
-	sqlp -= sqlR2[sqln]
+	sqlp -= int(sqlR2[sqln])
 	// sqlp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if sqlp+1 >= len(sqlS) {
@@ -875,16 +1062,16 @@ sqldefault:
 	sqlVAL = sqlS[sqlp+1]
 
 	/* consult goto table to find next state */
-	sqln = sqlR1[sqln]
-	sqlg := sqlPgo[sqln]
+	sqln = int(sqlR1[sqln])
+	sqlg := int(sqlPgo[sqln])
 	sqlj := sqlg + sqlS[sqlp].yys + 1
 
 	if sqlj >= sqlLast {
-		sqlstate = sqlAct[sqlg]
+		sqlstate = int(sqlAct[sqlg])
 	} else {
-		sqlstate = sqlAct[sqlj]
-		if sqlChk[sqlstate] != -sqln {
-			sqlstate = sqlAct[sqlg]
+		sqlstate = int(sqlAct[sqlj])
+		if int(sqlChk[sqlstate]) != -sqln {
+			sqlstate = int(sqlAct[sqlg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -892,711 +1079,1169 @@ sqldefault:
 
 	case 1:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:325
+//line sql.y:406
 		{
-			sqllex.(*lexer).SetStmt(sqlDollar[1].union.selectStatement())
+			sqllex.(*lexer).SetStmt(sqlDollar[1].union.statement())
 		}
 	case 2:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:327
+//line sql.y:408
 		{
 			sqlVAL.union.val = sqlDollar[1].union.selectStatement()
 		}
 	case 3:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:330
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:409
 		{
-			sqlVAL.union.val = &tree.Select{
-				Limit:    sqlDollar[3].union.limitStatement(),
-				Order:    sqlDollar[2].union.orderTopStatement(),
-				Relation: sqlDollar[1].union.relationStatement(),
-			}
+			sqlVAL.union.val = sqlDollar[1].union.insertStatement()
 		}
 	case 4:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:340
+//line sql.y:410
 		{
-			sqlVAL.union.val = sqlDollar[1].union.orderTopStatement()
+			sqlVAL.union.val = sqlDollar[1].union.createTableStatement()
 		}
 	case 5:
-		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:341
+		sqlDollar = sqlS[sqlpt-7 : sqlpt+1]
+//line sql.y:413
 		{
-			sqlVAL.union.val = nil
+			sqlVAL.union.val = &tree.CreateTable{
+				Table:   sqlDollar[3].union.tableName(),
+				Defs:    sqlDollar[5].union.tableDefs(),
+				Options: sqlDollar[7].union.options(),
+			}
 		}
 	case 6:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:343
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:421
 		{
-			sqlVAL.union.val = sqlDollar[3].union.orderByStatement()
+			sqlVAL.union.val = tree.TableDefs{sqlDollar[1].union.tableDef()}
 		}
 	case 7:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:344
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:422
 		{
-			sqlVAL.union.val = &tree.Top{
-				N: sqlDollar[2].union.exprStatement(),
-			}
+			sqlVAL.union.val = append(sqlDollar[1].union.tableDefs(), sqlDollar[3].union.tableDef())
 		}
 	case 8:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:348
+//line sql.y:424
 		{
-			sqlVAL.union.val = &tree.Ftop{
-				N: sqlDollar[2].union.exprStatement(),
-			}
+			sqlVAL.union.val = &tree.ColumnDef{Name: tree.Name(sqlDollar[1].str), Type: sqlDollar[2].str}
 		}
 	case 9:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:353
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:425
 		{
-			sqlVAL.union.val = tree.OrderBy{sqlDollar[1].union.orderStatement()}
+			sqlVAL.union.val = &tree.IndexDef{Cols: sqlDollar[3].union.nameList()}
 		}
 	case 10:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:354
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:427
 		{
-			sqlVAL.union.val = append(sqlDollar[1].union.orderByStatement(), sqlDollar[3].union.orderStatement())
+			sqlVAL.str = sqlDollar[1].str
 		}
 	case 11:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:357
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:428
 		{
-			sqlVAL.union.val = &tree.Order{
-				E:    sqlDollar[1].union.exprStatement(),
-				Type: sqlDollar[2].union.direction(),
-			}
+			sqlVAL.str = sqlDollar[1].str
 		}
 	case 12:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:364
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:429
 		{
-			sqlVAL.union.val = tree.Ascending
+			sqlVAL.str = sqlDollar[1].str + "(" + sqlDollar[3].union.valueStatement().String() + ")"
 		}
 	case 13:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:365
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:430
 		{
-			sqlVAL.union.val = tree.Descending
+			sqlVAL.str = sqlDollar[1].str + "(" + sqlDollar[3].str + ")"
 		}
 	case 14:
-		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:366
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:432
 		{
-			sqlVAL.union.val = tree.DefaultDirection
+			sqlVAL.union.val = sqlDollar[3].union.options()
 		}
 	case 15:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:369
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:433
 		{
-			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+			sqlVAL.union.val = nil
 		}
 	case 16:
-		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:370
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:435
 		{
-			sqlVAL.union.val = nil
+			sqlVAL.union.val = tree.Options{sqlDollar[1].union.option()}
 		}
 	case 17:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:373
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:436
 		{
-			if sqlDollar[1].union.limitStatement() == nil {
-				sqlVAL.union.val = sqlDollar[2].union.limitStatement()
-			} else {
-				sqlVAL.union.val = sqlDollar[1].union.limitStatement()
-				sqlVAL.union.val.(*tree.Limit).Offset = sqlDollar[2].union.limitStatement().Offset
-			}
+			sqlVAL.union.val = append(sqlDollar[1].union.options(), sqlDollar[3].union.option())
 		}
 	case 18:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:382
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:438
 		{
-			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
-			if sqlDollar[2].union.limitStatement() != nil {
-				sqlVAL.union.val.(*tree.Limit).Count = sqlDollar[2].union.limitStatement().Count
-			}
+			sqlVAL.union.val = &tree.Option{Name: tree.Name(sqlDollar[1].str), E: sqlDollar[3].union.valueStatement()}
 		}
 	case 19:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:389
+//line sql.y:440
 		{
-			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
 	case 20:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:393
+//line sql.y:441
 		{
-			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
 	case 21:
-		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
-//line sql.y:398
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:444
 		{
-			sqlVAL.union.val = &tree.Limit{Count: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = sqlDollar[4].union.insertStatement()
+			sqlVAL.union.val.(*tree.Insert).Table = sqlDollar[3].union.tableName()
 		}
 	case 22:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:402
+//line sql.y:449
 		{
-			sqlVAL.union.val = &tree.Limit{Offset: sqlDollar[2].union.exprStatement()}
+			sqlVAL.union.val = &tree.Insert{Values: sqlDollar[2].union.valuesList()}
 		}
 	case 23:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:403
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:451
 		{
-			sqlVAL.union.val = &tree.Limit{Offset: sqlDollar[2].union.exprStatement()}
+			sqlVAL.union.val = &tree.Insert{Columns: sqlDollar[2].union.nameList(), Values: sqlDollar[5].union.valuesList()}
 		}
 	case 24:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:405
+//line sql.y:452
 		{
-			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+			sqlVAL.union.val = &tree.Insert{Select: sqlDollar[1].union.selectStatement()}
 		}
 	case 25:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:406
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:454
 		{
-			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
+			sqlVAL.union.val = &tree.Insert{Columns: sqlDollar[2].union.nameList(), Select: sqlDollar[4].union.selectStatement()}
 		}
 	case 26:
-		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:407
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:457
 		{
-			sqlVAL.union.val = &tree.Value{value.NewInt(1)}
+			sqlVAL.union.val = &tree.Select{
+				Limit:    sqlDollar[3].union.limitStatement(),
+				Order:    sqlDollar[2].union.orderTopStatement(),
+				Relation: sqlDollar[1].union.simpleSelectStatement(),
+			}
 		}
 	case 27:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:409
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:465
 		{
+			sqlVAL.union.val = []tree.ExprStatements{sqlDollar[2].union.exprStatements()}
 		}
 	case 28:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:410
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:467
 		{
+			sqlVAL.union.val = append(sqlDollar[1].union.valuesList(), sqlDollar[4].union.exprStatements())
 		}
 	case 29:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:412
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:470
 		{
+			sqlVAL.union.val = &tree.Select{
+				Limit:    sqlDollar[3].union.limitStatement(),
+				Order:    sqlDollar[2].union.orderTopStatement(),
+				Relation: sqlDollar[1].union.relationStatement(),
+			}
 		}
 	case 30:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:413
+//line sql.y:480
 		{
+			sqlVAL.union.val = sqlDollar[1].union.orderTopStatement()
 		}
 	case 31:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:417
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:481
 		{
-			sqlVAL.union.val = &tree.AliasedTable{
-				As:  sqlDollar[2].union.aliasClause(),
-				Tbl: sqlDollar[1].union.tableName(),
-			}
+			sqlVAL.union.val = nil
 		}
 	case 32:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:422
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:483
 		{
-			sqlVAL.union.val = sqlDollar[1].union.joinStatement()
+			sqlVAL.union.val = sqlDollar[3].union.orderByStatement()
 		}
 	case 33:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:423
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:484
 		{
-			sqlVAL.union.val = sqlDollar[1].union.unionStatement()
+			sqlVAL.union.val = &tree.Top{
+				N: sqlDollar[2].union.exprStatement(),
+			}
 		}
 	case 34:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:424
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:489
 		{
-			sqlVAL.union.val = sqlDollar[1].union.simpleSelectStatement()
+			sqlVAL.union.val = &tree.Top{
+				N: sqlDollar[2].union.exprStatement(),
+				R: sqlDollar[4].union.exprStatement(),
+			}
 		}
 	case 35:
-		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:425
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:494
 		{
-			sqlVAL.union.val = &tree.AliasedSelect{
-				As:  sqlDollar[4].union.aliasClause(),
-				Sel: sqlDollar[2].union.selectStatement(),
+			sqlVAL.union.val = &tree.Ftop{
+				N: sqlDollar[2].union.exprStatement(),
 			}
 		}
 	case 36:
-		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
-//line sql.y:433
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:499
 		{
-			sqlVAL.union.val = &tree.SelectClause{
-				Distinct: false,
-				Sel:      sqlDollar[2].union.selectExprs(),
-				From:     sqlDollar[3].union.fromStatement(),
-				Where:    sqlDollar[4].union.whereStatement(),
-				Having:   sqlDollar[6].union.whereStatement(),
-				GroupBy:  sqlDollar[5].union.groupByStatement(),
+			sqlVAL.union.val = &tree.Top{
+				N:     sqlDollar[2].union.exprStatement(),
+				Order: sqlDollar[5].union.orderByStatement(),
 			}
 		}
 	case 37:
 		sqlDollar = sqlS[sqlpt-7 : sqlpt+1]
-//line sql.y:444
+//line sql.y:505
 		{
-			sqlVAL.union.val = &tree.SelectClause{
-				Distinct: sqlDollar[2].union.bool(),
-				Sel:      sqlDollar[3].union.selectExprs(),
-				From:     sqlDollar[4].union.fromStatement(),
-				Where:    sqlDollar[5].union.whereStatement(),
-				Having:   sqlDollar[7].union.whereStatement(),
-				GroupBy:  sqlDollar[6].union.groupByStatement(),
+			sqlVAL.union.val = &tree.Top{
+				N:     sqlDollar[2].union.exprStatement(),
+				R:     sqlDollar[4].union.exprStatement(),
+				Order: sqlDollar[7].union.orderByStatement(),
 			}
 		}
 	case 38:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:457
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:512
 		{
-			sqlVAL.union.val = true
+			sqlVAL.union.val = &tree.Ftop{
+				N:     sqlDollar[2].union.exprStatement(),
+				Order: sqlDollar[5].union.orderByStatement(),
+			}
 		}
 	case 39:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:462
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:518
 		{
-			if sqlDollar[1].union.isNull() {
-				sqlVAL.union.val = tree.SelectExprs{}
-			} else {
-				sqlVAL.union.val = tree.SelectExprs{sqlDollar[1].union.selectExpr()}
+			sqlVAL.union.val = &tree.Top{
+				N:     sqlDollar[5].union.exprStatement(),
+				Order: sqlDollar[3].union.orderByStatement(),
 			}
 		}
 	case 40:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:470
+		sqlDollar = sqlS[sqlpt-7 : sqlpt+1]
+//line sql.y:524
 		{
-			if sqlDollar[3].union.isNull() {
-				sqlVAL.union.val = sqlDollar[1].union.selectExprs()
-			} else {
-				sqlVAL.union.val = append(sqlDollar[1].union.selectExprs(), sqlDollar[3].union.selectExpr())
+			sqlVAL.union.val = &tree.Top{
+				N:     sqlDollar[5].union.exprStatement(),
+				R:     sqlDollar[7].union.exprStatement(),
+				Order: sqlDollar[3].union.orderByStatement(),
 			}
 		}
 	case 41:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:479
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:531
 		{
-			sqlVAL.union.val = &tree.SelectExpr{E: sqlDollar[1].union.exprStatement()}
+			sqlVAL.union.val = &tree.Ftop{
+				N:     sqlDollar[5].union.exprStatement(),
+				Order: sqlDollar[3].union.orderByStatement(),
+			}
 		}
 	case 42:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:483
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:537
 		{
-			sqlVAL.union.val = &tree.SelectExpr{E: sqlDollar[1].union.exprStatement(), As: tree.Name(sqlDollar[2].str)}
+			sqlVAL.union.val = tree.OrderBy{sqlDollar[1].union.orderStatement()}
 		}
 	case 43:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:487
+//line sql.y:538
 		{
-			sqlVAL.union.val = &tree.SelectExpr{E: sqlDollar[1].union.exprStatement(), As: tree.Name(sqlDollar[3].str)}
+			sqlVAL.union.val = append(sqlDollar[1].union.orderByStatement(), sqlDollar[3].union.orderStatement())
 		}
 	case 44:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:541
+		{
+			sqlVAL.union.val = &tree.Order{
+				E:    sqlDollar[1].union.exprStatement(),
+				Type: sqlDollar[2].union.direction(),
+			}
+		}
+	case 45:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:548
+		{
+			sqlVAL.union.val = tree.Ascending
+		}
+	case 46:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:491
+//line sql.y:549
+		{
+			sqlVAL.union.val = tree.Descending
+		}
+	case 47:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:550
+		{
+			sqlVAL.union.val = tree.DefaultDirection
+		}
+	case 48:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:553
+		{
+			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+		}
+	case 49:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:554
 		{
 			sqlVAL.union.val = nil
 		}
-	case 45:
+	case 50:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:557
+		{
+			if sqlDollar[1].union.limitStatement() == nil {
+				sqlVAL.union.val = sqlDollar[2].union.limitStatement()
+			} else {
+				sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+				sqlVAL.union.val.(*tree.Limit).Offset = sqlDollar[2].union.limitStatement().Offset
+			}
+		}
+	case 51:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:566
+		{
+			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+			if sqlDollar[2].union.limitStatement() != nil {
+				sqlVAL.union.val.(*tree.Limit).Count = sqlDollar[2].union.limitStatement().Count
+			}
+		}
+	case 52:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:573
+		{
+			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+		}
+	case 53:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:577
+		{
+			sqlVAL.union.val = sqlDollar[1].union.limitStatement()
+		}
+	case 54:
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:582
+		{
+			sqlVAL.union.val = &tree.Limit{Count: sqlDollar[3].union.exprStatement()}
+		}
+	case 55:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:586
+		{
+			sqlVAL.union.val = &tree.Limit{Offset: sqlDollar[2].union.exprStatement()}
+		}
+	case 56:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:587
+		{
+			sqlVAL.union.val = &tree.Limit{Offset: sqlDollar[2].union.exprStatement()}
+		}
+	case 57:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:589
+		{
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+		}
+	case 58:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:590
+		{
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+		}
+	case 59:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:591
+		{
+			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
+		}
+	case 60:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:592
+		{
+			sqlVAL.union.val = &tree.Value{value.NewInt(1)}
+		}
+	case 61:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:594
+		{
+		}
+	case 62:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:595
+		{
+		}
+	case 63:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:597
+		{
+		}
+	case 64:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:598
+		{
+		}
+	case 65:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:602
+		{
+			sqlVAL.union.val = &tree.AliasedTable{
+				As:  sqlDollar[2].union.aliasClause(),
+				Tbl: sqlDollar[1].union.tableName(),
+			}
+		}
+	case 66:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:607
+		{
+			sqlVAL.union.val = sqlDollar[1].union.joinStatement()
+		}
+	case 67:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:608
+		{
+			sqlVAL.union.val = sqlDollar[1].union.unionStatement()
+		}
+	case 68:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:609
+		{
+			sqlVAL.union.val = sqlDollar[1].union.simpleSelectStatement()
+		}
+	case 69:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:610
+		{
+			sqlVAL.union.val = &tree.AliasedSelect{
+				As:  sqlDollar[4].union.aliasClause(),
+				Sel: sqlDollar[2].union.selectStatement(),
+			}
+		}
+	case 70:
+		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
+//line sql.y:618
+		{
+			sqlVAL.union.val = &tree.SelectClause{
+				Distinct: false,
+				Sel:      sqlDollar[2].union.selectExprs(),
+				From:     sqlDollar[3].union.fromStatement(),
+				Where:    sqlDollar[4].union.whereStatement(),
+				Having:   sqlDollar[6].union.whereStatement(),
+				GroupBy:  sqlDollar[5].union.groupByStatement(),
+			}
+		}
+	case 71:
+		sqlDollar = sqlS[sqlpt-7 : sqlpt+1]
+//line sql.y:629
+		{
+			sqlVAL.union.val = &tree.SelectClause{
+				Distinct: sqlDollar[2].union.bool(),
+				Sel:      sqlDollar[3].union.selectExprs(),
+				From:     sqlDollar[4].union.fromStatement(),
+				Where:    sqlDollar[5].union.whereStatement(),
+				Having:   sqlDollar[7].union.whereStatement(),
+				GroupBy:  sqlDollar[6].union.groupByStatement(),
+			}
+		}
+	case 72:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:642
+		{
+			sqlVAL.union.val = true
+		}
+	case 73:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:647
+		{
+			if sqlDollar[1].union.isNull() {
+				sqlVAL.union.val = tree.SelectExprs{}
+			} else {
+				sqlVAL.union.val = tree.SelectExprs{sqlDollar[1].union.selectExpr()}
+			}
+		}
+	case 74:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:655
+		{
+			if sqlDollar[3].union.isNull() {
+				sqlVAL.union.val = sqlDollar[1].union.selectExprs()
+			} else {
+				sqlVAL.union.val = append(sqlDollar[1].union.selectExprs(), sqlDollar[3].union.selectExpr())
+			}
+		}
+	case 75:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:664
+		{
+			sqlVAL.union.val = &tree.SelectExpr{E: sqlDollar[1].union.exprStatement()}
+		}
+	case 76:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:668
+		{
+			sqlVAL.union.val = &tree.SelectExpr{E: sqlDollar[1].union.exprStatement(), As: tree.Name(sqlDollar[2].str)}
+		}
+	case 77:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:672
+		{
+			sqlVAL.union.val = &tree.SelectExpr{E: sqlDollar[1].union.exprStatement(), As: tree.Name(sqlDollar[3].str)}
+		}
+	case 78:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:676
+		{
+			sqlVAL.union.val = nil
+		}
+	case 79:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:498
+//line sql.y:683
 		{
 			sqlVAL.union.val = &tree.From{sqlDollar[2].union.tableStatements()}
 		}
-	case 46:
+	case 80:
 		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:501
+//line sql.y:686
 		{
 			sqlVAL.union.val = nil
 		}
-	case 47:
+	case 81:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:504
+//line sql.y:689
 		{
 			sqlVAL.union.val = tree.TableStatements{sqlDollar[1].union.tableStatement()}
 		}
-	case 48:
+	case 82:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:508
+//line sql.y:693
 		{
 			sqlVAL.union.val = append(sqlDollar[1].union.tableStatements(), sqlDollar[3].union.tableStatement())
 		}
-	case 49:
+	case 83:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:515
+//line sql.y:700
 		{
 			sqlVAL.union.val = &tree.Where{Type: tree.AstWhere, E: sqlDollar[1].union.exprStatement()}
 		}
-	case 50:
+	case 84:
 		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:518
+//line sql.y:703
+		{
+			sqlVAL.union.val = nil
+		}
+	case 85:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:705
+		{
+			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
+		}
+	case 86:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:709
+		{
+			sqlVAL.union.val = &tree.GroupBy{sqlDollar[3].union.exprStatements()}
+		}
+	case 87:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:710
+		{
+			sqlVAL.union.val = nil
+		}
+	case 88:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:715
+		{
+			sqlVAL.union.val = &tree.Where{Type: tree.AstHaving, E: sqlDollar[2].union.exprStatement()}
+		}
+	case 89:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:718
+		{
+			sqlVAL.union.val = nil
+		}
+	case 90:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:722
+		{
+			sqlVAL.union.val = tree.ExprStatements{sqlDollar[1].union.exprStatement()}
+		}
+	case 91:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:723
+		{
+			sqlVAL.union.val = append(sqlDollar[1].union.exprStatements(), sqlDollar[3].union.exprStatement())
+		}
+	case 92:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:725
+		{
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+		}
+	case 93:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:726
+		{
+			sqlVAL.union.val = &tree.NotExpr{E: sqlDollar[2].union.exprStatement()}
+		}
+	case 94:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:727
+		{
+			sqlVAL.union.val = &tree.OrExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 95:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:728
+		{
+			sqlVAL.union.val = &tree.AndExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 96:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:729
+		{
+			sqlVAL.union.val = &tree.IsNullExpr{E: sqlDollar[1].union.exprStatement()}
+		}
+	case 97:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:730
+		{
+			sqlVAL.union.val = &tree.IsNotNullExpr{E: sqlDollar[1].union.exprStatement()}
+		}
+	case 98:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:731
+		{
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+		}
+	case 99:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:733
+		{
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+		}
+	case 100:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:734
+		{
+			sqlVAL.union.val = sqlDollar[1].union.colunmNameList()
+		}
+	case 101:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:735
+		{
+			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
+		}
+	case 102:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:736
+		{
+			sqlVAL.union.val = &tree.UnaryMinusExpr{E: sqlDollar[2].union.exprStatement()}
+		}
+	case 103:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:737
+		{
+			sqlVAL.union.val = &tree.PlusExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 104:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:738
+		{
+			sqlVAL.union.val = &tree.MinusExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 105:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:739
+		{
+			sqlVAL.union.val = &tree.MultExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 106:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:740
+		{
+			sqlVAL.union.val = &tree.DivExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 107:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:741
+		{
+			sqlVAL.union.val = &tree.ModExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 108:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:742
+		{
+			sqlVAL.union.val = sqlDollar[1].union.funcStatement()
+		}
+	case 109:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:744
+		{
+			sqlVAL.union.val = &tree.LtExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 110:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:745
+		{
+			sqlVAL.union.val = &tree.GtExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 111:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:746
+		{
+			sqlVAL.union.val = &tree.EqExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 112:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:747
+		{
+			sqlVAL.union.val = &tree.LeExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 113:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:748
+		{
+			sqlVAL.union.val = &tree.GeExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 114:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:749
+		{
+			sqlVAL.union.val = &tree.NeExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+		}
+	case 115:
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:750
+		{
+			sqlVAL.union.val = &tree.BetweenExpr{E: sqlDollar[1].union.exprStatement(), From: sqlDollar[3].union.exprStatement(), To: sqlDollar[5].union.exprStatement()}
+		}
+	case 116:
+		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
+//line sql.y:751
+		{
+			sqlVAL.union.val = &tree.NotBetweenExpr{E: sqlDollar[1].union.exprStatement(), From: sqlDollar[4].union.exprStatement(), To: sqlDollar[6].union.exprStatement()}
+		}
+	case 117:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:752
 		{
-			sqlVAL.union.val = nil
+			sqlVAL.union.val = &tree.InExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.subqueryStatement()}
 		}
-	case 51:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:520
+	case 118:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:753
 		{
-			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
+			sqlVAL.union.val = &tree.NotInExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[4].union.subqueryStatement()}
 		}
-	case 52:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:524
+	case 119:
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:754
 		{
-			sqlVAL.union.val = &tree.GroupBy{sqlDollar[3].union.exprStatements()}
+			sqlVAL.union.val = &tree.InExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[4].union.exprStatements()}
 		}
-	case 53:
-		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:525
+	case 120:
+		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
+//line sql.y:755
 		{
-			sqlVAL.union.val = nil
+			sqlVAL.union.val = &tree.NotInExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[5].union.exprStatements()}
 		}
-	case 54:
+	case 121:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:530
+//line sql.y:756
 		{
-			sqlVAL.union.val = &tree.Where{Type: tree.AstHaving, E: sqlDollar[2].union.exprStatement()}
+			sqlVAL.union.val = sqlDollar[2].union.subqueryStatement()
+			sqlVAL.union.val.(*tree.Subquery).Exists = true
 		}
-	case 55:
-		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:533
+	case 122:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:761
 		{
-			sqlVAL.union.val = nil
+			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 56:
+	case 123:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:537
+//line sql.y:762
 		{
-			sqlVAL.union.val = tree.ExprStatements{sqlDollar[1].union.exprStatement()}
+			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 57:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:538
+	case 124:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:763
 		{
-			sqlVAL.union.val = append(sqlDollar[1].union.exprStatements(), sqlDollar[3].union.exprStatement())
+			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 58:
+	case 125:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:540
+//line sql.y:764
 		{
 			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
 		}
-	case 59:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:541
+	case 126:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:765
 		{
-			sqlVAL.union.val = &tree.NotExpr{E: sqlDollar[2].union.exprStatement()}
+			sqlVAL.union.val = &tree.Value{&value.ConstTrue}
 		}
-	case 60:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:542
+	case 127:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:766
 		{
-			sqlVAL.union.val = &tree.OrExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = &tree.Value{&value.ConstFalse}
 		}
-	case 61:
+	case 128:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:767
+		{
+			sqlVAL.union.val = &tree.Value{value.ConstNull}
+		}
+	case 129:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:543
+//line sql.y:768
 		{
-			sqlVAL.union.val = &tree.AndExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = &tree.ParenExpr{sqlDollar[2].union.exprStatement()}
 		}
-	case 62:
+	case 130:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:769
+		{
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+		}
+	case 131:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:544
+//line sql.y:771
 		{
-			sqlVAL.union.val = &tree.IsNullExpr{E: sqlDollar[1].union.exprStatement()}
+			sqlVAL.union.val = &tree.IntervalExpr{N: sqlDollar[2].union.valueStatement(), Unit: sqlDollar[3].str}
 		}
-	case 63:
-		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:545
+	case 132:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:773
 		{
-			sqlVAL.union.val = &tree.IsNotNullExpr{E: sqlDollar[1].union.exprStatement()}
+			sqlVAL.union.val = &tree.Value{value.NewVector(sqlDollar[2].union.float32s())}
 		}
-	case 64:
-		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:546
+	case 133:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:774
 		{
-			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+			sqlVAL.union.val = &tree.Value{value.NewVector([]float32{})}
 		}
-	case 65:
+	case 134:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:548
+//line sql.y:776
 		{
-			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
+			sqlVAL.union.val = []float32{sqlDollar[1].union.float32()}
 		}
-	case 66:
+	case 135:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:549
+//line sql.y:777
 		{
-			sqlVAL.union.val = sqlDollar[1].union.colunmNameList()
+			sqlVAL.union.val = []float32{sqlDollar[1].union.float32()}
 		}
-	case 67:
+	case 136:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:550
+//line sql.y:778
 		{
-			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
+			sqlVAL.union.val = []float32{-sqlDollar[2].union.float32()}
 		}
-	case 68:
+	case 137:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:551
+//line sql.y:779
 		{
-			sqlVAL.union.val = &tree.UnaryMinusExpr{E: sqlDollar[2].union.exprStatement()}
+			sqlVAL.union.val = []float32{-sqlDollar[2].union.float32()}
 		}
-	case 69:
+	case 138:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:552
+//line sql.y:780
 		{
-			sqlVAL.union.val = &tree.PlusExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = append(sqlDollar[1].union.float32s(), sqlDollar[3].union.float32())
 		}
-	case 70:
+	case 139:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:553
+//line sql.y:781
 		{
-			sqlVAL.union.val = &tree.MinusExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = append(sqlDollar[1].union.float32s(), sqlDollar[3].union.float32())
 		}
-	case 71:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:554
+	case 140:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:782
 		{
-			sqlVAL.union.val = &tree.MultExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = append(sqlDollar[1].union.float32s(), -sqlDollar[4].union.float32())
 		}
-	case 72:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:555
+	case 141:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:783
 		{
-			sqlVAL.union.val = &tree.DivExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = append(sqlDollar[1].union.float32s(), -sqlDollar[4].union.float32())
 		}
-	case 73:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:556
+	case 142:
+		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
+//line sql.y:786
 		{
-			sqlVAL.union.val = &tree.ModExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = &tree.CaseExpr{E: sqlDollar[2].union.optExprStatement(), Whens: sqlDollar[3].union.whens(), Else: sqlDollar[4].union.optExprStatement()}
 		}
-	case 74:
+	case 143:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:557
+//line sql.y:790
 		{
-			sqlVAL.union.val = sqlDollar[1].union.funcStatement()
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
 		}
-	case 75:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:559
+	case 144:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:791
 		{
-			sqlVAL.union.val = &tree.LtExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = nil
 		}
-	case 76:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:560
+	case 145:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:793
 		{
-			sqlVAL.union.val = &tree.GtExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = []*tree.When{sqlDollar[1].union.when()}
 		}
-	case 77:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:561
+	case 146:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:794
 		{
-			sqlVAL.union.val = &tree.EqExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = append(sqlDollar[1].union.whens(), sqlDollar[2].union.when())
 		}
-	case 78:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:562
+	case 147:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:796
 		{
-			sqlVAL.union.val = &tree.LeExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = &tree.When{Cond: sqlDollar[2].union.exprStatement(), Val: sqlDollar[4].union.exprStatement()}
 		}
-	case 79:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:563
+	case 148:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:798
 		{
-			sqlVAL.union.val = &tree.GeExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = sqlDollar[2].union.exprStatement()
 		}
-	case 80:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:564
+	case 149:
+		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
+//line sql.y:799
 		{
-			sqlVAL.union.val = &tree.NeExpr{Left: sqlDollar[1].union.exprStatement(), Right: sqlDollar[3].union.exprStatement()}
+			sqlVAL.union.val = nil
 		}
-	case 81:
-		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
-//line sql.y:565
+	case 150:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:801
 		{
-			sqlVAL.union.val = &tree.BetweenExpr{E: sqlDollar[1].union.exprStatement(), From: sqlDollar[3].union.exprStatement(), To: sqlDollar[5].union.exprStatement()}
+			sqlVAL.union.val = tree.ExprStatements{sqlDollar[1].union.exprStatement()}
 		}
-	case 82:
-		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
-//line sql.y:566
+	case 151:
+		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
+//line sql.y:802
 		{
-			sqlVAL.union.val = &tree.NotBetweenExpr{E: sqlDollar[1].union.exprStatement(), From: sqlDollar[4].union.exprStatement(), To: sqlDollar[6].union.exprStatement()}
+			sqlVAL.union.val = append(sqlDollar[1].union.exprStatements(), sqlDollar[3].union.exprStatement())
 		}
-	case 83:
-		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:567
+	case 152:
+		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
+//line sql.y:804
 		{
-			sqlVAL.union.val = sqlDollar[2].union.subqueryStatement()
-			sqlVAL.union.val.(*tree.Subquery).Exists = true
+			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 84:
+	case 153:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:572
+//line sql.y:805
 		{
 			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 85:
+	case 154:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:573
+//line sql.y:806
 		{
 			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 86:
+	case 155:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:574
+//line sql.y:807
 		{
-			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
+			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
 		}
-	case 87:
+	case 156:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:575
+//line sql.y:808
 		{
 			sqlVAL.union.val = &tree.Value{&value.ConstTrue}
 		}
-	case 88:
+	case 157:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:576
+//line sql.y:809
 		{
 			sqlVAL.union.val = &tree.Value{&value.ConstFalse}
 		}
-	case 89:
+	case 158:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:577
+//line sql.y:810
 		{
 			sqlVAL.union.val = &tree.Value{value.ConstNull}
 		}
-	case 90:
-		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:578
+	case 159:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:811
 		{
-			sqlVAL.union.val = &tree.ParenExpr{sqlDollar[2].union.exprStatement()}
+			sqlVAL.union.val = sqlDollar[2].union.negative()
 		}
-	case 91:
+	case 160:
+		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
+//line sql.y:812
+		{
+			sqlVAL.union.val = sqlDollar[2].union.negative()
+		}
+	case 161:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:580
+//line sql.y:814
 		{
 			sqlVAL.union.val = sqlDollar[1].union.valueStatement()
 		}
-	case 92:
+	case 162:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:581
+//line sql.y:815
 		{
 			sqlVAL.union.val = sqlDollar[2].union.valueStatement()
 		}
-	case 93:
+	case 163:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:582
+//line sql.y:816
 		{
 			sqlVAL.union.val = sqlDollar[2].union.setNegative()
 		}
-	case 94:
+	case 164:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:587
+//line sql.y:821
 		{
 			sqlVAL.union.val = sqlDollar[1].union.funcStatement()
 		}
-	case 95:
+	case 165:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:591
+//line sql.y:825
 		{
 			sqlVAL.union.val = sqlDollar[1].union.funcStatement()
 		}
-	case 96:
+	case 166:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:596
+//line sql.y:830
 		{
 			sqlVAL.union.val = &tree.FuncExpr{Name: sqlDollar[1].str}
 		}
-	case 97:
+	case 167:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:600
+//line sql.y:834
 		{
 			sqlVAL.union.val = &tree.FuncExpr{Name: sqlDollar[1].str, Es: sqlDollar[3].union.exprStatements()}
 		}
-	case 98:
+	case 168:
+		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
+//line sql.y:838
+		{
+			sqlVAL.union.val = &tree.FuncExpr{Name: sqlDollar[1].str, Es: tree.ExprStatements{&tree.StarExpr{}}}
+		}
+	case 169:
 		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
-//line sql.y:605
+//line sql.y:843
 		{
 			sqlVAL.union.val = &tree.FuncExpr{Name: "cast", Es: tree.ExprStatements{sqlDollar[3].union.exprStatement(), sqlDollar[5].union.exprStatement()}}
 		}
-	case 99:
+	case 170:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:609
+//line sql.y:847
 		{
 			sqlVAL.union.val = sqlDollar[1].union.exprStatement()
 		}
-	case 100:
+	case 171:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:611
+//line sql.y:849
 		{
 			sqlVAL.union.val = &tree.Value{value.NewString("int")}
 		}
-	case 101:
+	case 172:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:612
+//line sql.y:850
 		{
 			sqlVAL.union.val = &tree.Value{value.NewString("bool")}
 		}
-	case 102:
+	case 173:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:613
+//line sql.y:851
 		{
 			sqlVAL.union.val = &tree.Value{value.NewString("time")}
 		}
-	case 103:
+	case 174:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:614
+//line sql.y:852
 		{
 			sqlVAL.union.val = &tree.Value{value.NewString("float")}
 		}
-	case 104:
+	case 175:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:615
+//line sql.y:853
 		{
 			sqlVAL.union.val = &tree.Value{value.NewString("string")}
 		}
-	case 105:
+	case 176:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:620
+//line sql.y:858
 		{
 			sqlVAL.union.val = &tree.AliasClause{Alias: tree.Name(sqlDollar[2].str), Cols: sqlDollar[3].union.nameList()}
 		}
-	case 106:
+	case 177:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:624
+//line sql.y:862
 		{
 			sqlVAL.union.val = &tree.AliasClause{Alias: tree.Name(sqlDollar[1].str), Cols: sqlDollar[2].union.nameList()}
 		}
-	case 107:
+	case 178:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:628
+//line sql.y:866
 		{
 			sqlVAL.union.val = sqlDollar[1].union.aliasClause()
 		}
-	case 108:
+	case 179:
 		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:629
+//line sql.y:867
 		{
 			sqlVAL.union.val = nil
 		}
-	case 109:
+	case 180:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:633
+//line sql.y:871
 		{
 			sqlVAL.union.val = &tree.Subquery{Select: sqlDollar[2].union.selectStatement(), Exists: false}
 		}
-	case 110:
+	case 181:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:636
+//line sql.y:874
 		{
 			sqlVAL.union.val = sqlDollar[1].union.relationStatement()
 		}
-	case 111:
+	case 182:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:641
+//line sql.y:879
 		{
 			sqlVAL.union.val = &tree.UnionClause{
 				Type:  tree.UnionOp,
@@ -1605,9 +2250,9 @@ sqldefault:
 				All:   sqlDollar[3].union.bool(),
 			}
 		}
-	case 112:
+	case 183:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:650
+//line sql.y:888
 		{
 			sqlVAL.union.val = &tree.UnionClause{
 				Type:  tree.IntersectOp,
@@ -1616,9 +2261,9 @@ sqldefault:
 				All:   sqlDollar[3].union.bool(),
 			}
 		}
-	case 113:
+	case 184:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:659
+//line sql.y:897
 		{
 			sqlVAL.union.val = &tree.UnionClause{
 				Type:  tree.ExceptOp,
@@ -1627,27 +2272,27 @@ sqldefault:
 				All:   sqlDollar[3].union.bool(),
 			}
 		}
-	case 114:
+	case 185:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:668
+//line sql.y:906
 		{
 			sqlVAL.union.val = true
 		}
-	case 115:
+	case 186:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:669
+//line sql.y:907
 		{
 			sqlVAL.union.val = false
 		}
-	case 116:
+	case 187:
 		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:670
+//line sql.y:908
 		{
 			sqlVAL.union.val = false
 		}
-	case 117:
+	case 188:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:675
+//line sql.y:913
 		{
 			sqlVAL.union.val = &tree.JoinClause{
 				Type:  tree.CrossOp,
@@ -1656,9 +2301,9 @@ sqldefault:
 				Right: sqlDollar[4].union.relationStatement(),
 			}
 		}
-	case 118:
+	case 189:
 		sqlDollar = sqlS[sqlpt-5 : sqlpt+1]
-//line sql.y:684
+//line sql.y:922
 		{
 			sqlVAL.union.val = &tree.JoinClause{
 				Type:  sqlDollar[2].union.joinType(),
@@ -1667,9 +2312,9 @@ sqldefault:
 				Right: sqlDollar[4].union.relationStatement(),
 			}
 		}
-	case 119:
+	case 190:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:693
+//line sql.y:931
 		{
 			sqlVAL.union.val = &tree.JoinClause{
 				Type:  tree.InnerOp,
@@ -1678,9 +2323,9 @@ sqldefault:
 				Right: sqlDollar[3].union.relationStatement(),
 			}
 		}
-	case 120:
+	case 191:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:702
+//line sql.y:940
 		{
 			sqlVAL.union.val = &tree.JoinClause{
 				Type:  tree.NaturalOp,
@@ -1689,115 +2334,115 @@ sqldefault:
 				Right: sqlDollar[4].union.relationStatement(),
 			}
 		}
-	case 121:
+	case 192:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:711
+//line sql.y:949
 		{
 			sqlVAL.union.val = &tree.OnJoinCond{E: sqlDollar[2].union.exprStatement()}
 		}
-	case 122:
+	case 193:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:713
+//line sql.y:951
 		{
 			sqlVAL.union.val = tree.FullOp
 		}
-	case 123:
+	case 194:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:714
+//line sql.y:952
 		{
 			sqlVAL.union.val = tree.LeftOp
 		}
-	case 124:
+	case 195:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:715
+//line sql.y:953
 		{
 			sqlVAL.union.val = tree.RightOp
 		}
-	case 125:
+	case 196:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:716
+//line sql.y:954
 		{
 			sqlVAL.union.val = tree.InnerOp
 		}
-	case 126:
+	case 197:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:718
+//line sql.y:956
 		{
 		}
-	case 127:
+	case 198:
 		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:719
+//line sql.y:957
 		{
 		}
-	case 128:
+	case 199:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:724
+//line sql.y:962
 		{
 			sqlVAL.union.val = &tree.AliasedTable{
 				Tbl: sqlDollar[1].union.tableName(),
 				As:  sqlDollar[2].union.aliasClause(),
 			}
 		}
-	case 129:
+	case 200:
 		sqlDollar = sqlS[sqlpt-2 : sqlpt+1]
-//line sql.y:731
+//line sql.y:969
 		{
 			sqlVAL.union.val = &tree.AliasedTable{
 				Tbl: sqlDollar[1].union.subqueryStatement(),
 				As:  sqlDollar[2].union.aliasClause(),
 			}
 		}
-	case 130:
+	case 201:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:741
+//line sql.y:979
 		{
 			sqlVAL.union.val = &tree.TableName{sqlDollar[1].union.colunmNameList()}
 		}
-	case 131:
+	case 202:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:748
+//line sql.y:986
 		{
 			sqlVAL.union.val = tree.ColunmNameList{tree.ColunmName{Path: tree.Name(sqlDollar[1].str)}}
 		}
-	case 132:
+	case 203:
 		sqlDollar = sqlS[sqlpt-4 : sqlpt+1]
-//line sql.y:752
+//line sql.y:990
 		{
 			sqlVAL.union.val = tree.ColunmNameList{tree.ColunmName{Path: tree.Name(sqlDollar[1].str), Index: sqlDollar[3].union.exprStatement()}}
 		}
-	case 133:
+	case 204:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:756
+//line sql.y:994
 		{
 			sqlVAL.union.val = append(sqlDollar[1].union.colunmNameList(), tree.ColunmName{Path: tree.Name(sqlDollar[3].str)})
 		}
-	case 134:
+	case 205:
 		sqlDollar = sqlS[sqlpt-6 : sqlpt+1]
-//line sql.y:760
+//line sql.y:998
 		{
 			sqlVAL.union.val = append(sqlDollar[1].union.colunmNameList(), tree.ColunmName{Path: tree.Name(sqlDollar[3].str), Index: sqlDollar[5].union.exprStatement()})
 		}
-	case 135:
+	case 206:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:765
+//line sql.y:1003
 		{
 			sqlVAL.union.val = sqlDollar[2].union.nameList()
 		}
-	case 136:
+	case 207:
 		sqlDollar = sqlS[sqlpt-0 : sqlpt+1]
-//line sql.y:766
+//line sql.y:1004
 		{
 			sqlVAL.union.val = tree.NameList(nil)
 		}
-	case 137:
+	case 208:
 		sqlDollar = sqlS[sqlpt-1 : sqlpt+1]
-//line sql.y:769
+//line sql.y:1007
 		{
 			sqlVAL.union.val = tree.NameList{tree.Name(sqlDollar[1].str)}
 		}
-	case 138:
+	case 209:
 		sqlDollar = sqlS[sqlpt-3 : sqlpt+1]
-//line sql.y:773
+//line sql.y:1011
 		{
 			sqlVAL.union.val = append(sqlDollar[1].union.nameList(), tree.Name(sqlDollar[3].str))
 		}
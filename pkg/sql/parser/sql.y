@@ -31,14 +31,71 @@ func (u *sqlSymUnion) setNegative() *tree.Value{
     return &tree.Value{value.NewInt(int64(value.MustBeInt(v.E))*-1)}
 }
 
+func (u *sqlSymUnion) negative() *tree.Value {
+    switch v := u.val.(*tree.Value).E.(type) {
+    case *value.Float:
+        return &tree.Value{value.NewFloat(float64(*v)*-1)}
+    default:
+        return &tree.Value{value.NewInt(int64(value.MustBeInt(v))*-1)}
+    }
+}
+
+func (u *sqlSymUnion) float32() float32 {
+    switch v := u.val.(*tree.Value).E.(type) {
+    case *value.Float:
+        return float32(*v)
+    default:
+        return float32(value.MustBeInt(v))
+    }
+}
+
+func (u *sqlSymUnion) float32s() []float32 {
+    return u.val.([]float32)
+}
+
 func (u *sqlSymUnion) valueStatement() *tree.Value {
     return u.val.(*tree.Value)
 }
 
+func (u *sqlSymUnion) statement() tree.Statement {
+    return u.val.(tree.Statement)
+}
+
 func (u *sqlSymUnion) selectStatement() *tree.Select {
     return u.val.(*tree.Select)
 }
 
+func (u *sqlSymUnion) insertStatement() *tree.Insert {
+    return u.val.(*tree.Insert)
+}
+
+func (u *sqlSymUnion) valuesList() []tree.ExprStatements {
+    return u.val.([]tree.ExprStatements)
+}
+
+func (u *sqlSymUnion) createTableStatement() *tree.CreateTable {
+    return u.val.(*tree.CreateTable)
+}
+
+func (u *sqlSymUnion) tableDef() tree.TableDef {
+    return u.val.(tree.TableDef)
+}
+
+func (u *sqlSymUnion) tableDefs() tree.TableDefs {
+    return u.val.(tree.TableDefs)
+}
+
+func (u *sqlSymUnion) option() *tree.Option {
+    return u.val.(*tree.Option)
+}
+
+func (u *sqlSymUnion) options() tree.Options {
+    if u.val == nil {
+        return nil
+    }
+    return u.val.(tree.Options)
+}
+
 func (u *sqlSymUnion) limitStatement() *tree.Limit {
     if u.val == nil {
         return nil
@@ -159,6 +216,21 @@ func (u *sqlSymUnion) exprStatement() tree.ExprStatement {
     return u.val.(tree.ExprStatement)
 }
 
+func (u *sqlSymUnion) optExprStatement() tree.ExprStatement {
+    if u.val == nil {
+        return nil
+    }
+    return u.val.(tree.ExprStatement)
+}
+
+func (u *sqlSymUnion) when() *tree.When {
+    return u.val.(*tree.When)
+}
+
+func (u *sqlSymUnion) whens() []*tree.When {
+    return u.val.([]*tree.When)
+}
+
 func (u *sqlSymUnion) exprStatements() tree.ExprStatements {
     return u.val.(tree.ExprStatements)
 }
@@ -178,6 +250,7 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 
 %token <str> IDENT
 %token <union> ICONST FCONST SCONST
+%token <union> PLACEHOLDER
 %token <str> LESS_EQUALS GREATER_EQUALS NOT_EQUALS
 
 %token <str> ALL AND AS ASC
@@ -185,13 +258,13 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 %token <str> BETWEEN
 %token <str> BOOL BY
 
-%token <str> CAST
-%token <str> CROSS
+%token <str> CASE CAST
+%token <str> CREATE CROSS
 
 %token <str> DESC
 %token <str> DISTINCT
 
-%token <str> EXCEPT
+%token <str> ELSE END EXCEPT
 %token <str> EXISTS
 
 %token <str> FTOP
@@ -202,8 +275,8 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 
 %token <str> HAVING
 
-%token <str> INNER INT
-%token <str> INTERSECT IS
+%token <str> IN INDEX INNER INSERT INT
+%token <str> INTERSECT INTERVAL INTO IS
 
 %token <str> JOIN
 
@@ -213,18 +286,20 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 %token <str> OFFSET ON ONLY OR
 %token <str> ORDER OUTER
 
-%token <str> RIGHT
+%token <str> RERANK RIGHT
 %token <str> ROW ROWS
 
 %token <str> SELECT
 %token <str> STRING
 
-%token <str> TOP
-%token <str> TIME TRUE
+%token <str> TABLE TOP
+%token <str> THEN TIME TRUE
 
 %token <str> UNION
 
-%token <str> WHERE
+%token <str> VALUES
+
+%token <str> WHEN WHERE WITH
 
 %token <str> NOT_LA
 
@@ -240,6 +315,9 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 %type <union> stmt
 
 %type <union> select_stmt
+%type <union> insert_stmt insert_rest insert_select values_list
+%type <union> create_stmt table_def table_def_list opt_with_options option option_list option_value
+%type <str> type_name
 
 %type <union> relation
 
@@ -265,7 +343,7 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 %type <union> name_list
 %type <union> from_clause
 %type <union> from_list
-%type <union> expr_list
+%type <union> expr_list in_list in_value
 %type <union> target_list
 %type <union> group_clause
 %type <union> fetch_clause opt_fetch_clause
@@ -293,9 +371,12 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 %type <union> cast_target
 
 %type <union> signed_iconst
+%type <union> vector_list
 
 %type <union> func_application func_expr_common_subexpr
 %type <union> func_expr
+%type <union> case_expr case_arg case_default
+%type <union> when_clause when_clause_list
 
 %type <byt> '+' '-' '*' '/' '%' '<' '>' '=' '[' ']' '(' ')' '.'
 
@@ -322,9 +403,68 @@ func (u *sqlSymUnion) aliasClause() *tree.AliasClause {
 
 %%
 
-stmt_block: stmt    { sqllex.(*lexer).SetStmt($1.selectStatement()) }
+stmt_block: stmt    { sqllex.(*lexer).SetStmt($1.statement()) }
 
 stmt: select_stmt   { $$.val = $1.selectStatement() }
+    | insert_stmt   { $$.val = $1.insertStatement() }
+    | create_stmt   { $$.val = $1.createTableStatement() }
+
+create_stmt: CREATE TABLE table_name '(' table_def_list ')' opt_with_options
+             {
+                $$.val = &tree.CreateTable{
+                    Table:      $3.tableName(),
+                    Defs:       $5.tableDefs(),
+                    Options:    $7.options(),
+                }
+             }
+
+table_def_list: table_def                       { $$.val = tree.TableDefs{$1.tableDef()} }
+              | table_def_list ',' table_def    { $$.val = append($1.tableDefs(), $3.tableDef()) }
+
+table_def: name type_name                       { $$.val = &tree.ColumnDef{Name: tree.Name($1), Type: $2} }
+         | INDEX '(' name_list ')'              { $$.val = &tree.IndexDef{Cols: $3.nameList()} }
+
+type_name: IDENT                                { $$ = $1 }
+         | STRING                               { $$ = $1 }
+         | IDENT '(' ICONST ')'                 { $$ = $1 + "(" + $3.valueStatement().String() + ")" }
+         | IDENT '(' type_name ')'              { $$ = $1 + "(" + $3 + ")" }
+
+opt_with_options: WITH '(' option_list ')'      { $$.val = $3.options() }
+                |                               { $$.val = nil }
+
+option_list: option                             { $$.val = tree.Options{$1.option()} }
+           | option_list ',' option             { $$.val = append($1.options(), $3.option()) }
+
+option: name '=' option_value                   { $$.val = &tree.Option{Name: tree.Name($1), E: $3.valueStatement()} }
+
+option_value: SCONST                            { $$.val = $1.valueStatement() }
+            | signed_iconst                     { $$.val = $1.valueStatement() }
+
+insert_stmt: INSERT INTO table_name insert_rest
+             {
+                $$.val = $4.insertStatement()
+                $$.val.(*tree.Insert).Table = $3.tableName()
+             }
+
+insert_rest: VALUES values_list     { $$.val = &tree.Insert{Values: $2.valuesList()} }
+           | '(' name_list ')' VALUES values_list
+                                    { $$.val = &tree.Insert{Columns: $2.nameList(), Values: $5.valuesList()} }
+           | insert_select          { $$.val = &tree.Insert{Select: $1.selectStatement()} }
+           | '(' name_list ')' insert_select
+                                    { $$.val = &tree.Insert{Columns: $2.nameList(), Select: $4.selectStatement()} }
+
+insert_select: simple_select opt_order_clause opt_fetch_clause
+               {
+                  $$.val = &tree.Select{
+                      Limit:      $3.limitStatement(),
+                      Order:      $2.orderTopStatement(),
+                      Relation:   $1.simpleSelectStatement(),
+                  }
+               }
+
+values_list: '(' expr_list ')'      { $$.val = []tree.ExprStatements{$2.exprStatements()} }
+           | values_list ',' '(' expr_list ')'
+                                    { $$.val = append($1.valuesList(), $4.exprStatements()) }
 
 select_stmt: relation opt_order_clause opt_fetch_clause
              {
@@ -345,10 +485,54 @@ order_clause: ORDER BY order_list   { $$.val = $3.orderByStatement() }
                                         N: $2.exprStatement(),
                                       }
                                     }
+            | TOP a_expr RERANK a_expr
+                                    { $$.val = &tree.Top{
+                                        N: $2.exprStatement(),
+                                        R: $4.exprStatement(),
+                                      }
+                                    }
             | FTOP a_expr           { $$.val = &tree.Ftop{
                                         N: $2.exprStatement(),
                                       }
                                     }
+            | TOP a_expr ORDER BY order_list
+                                    { $$.val = &tree.Top{
+                                        N:     $2.exprStatement(),
+                                        Order: $5.orderByStatement(),
+                                      }
+                                    }
+            | TOP a_expr RERANK a_expr ORDER BY order_list
+                                    { $$.val = &tree.Top{
+                                        N:     $2.exprStatement(),
+                                        R:     $4.exprStatement(),
+                                        Order: $7.orderByStatement(),
+                                      }
+                                    }
+            | FTOP a_expr ORDER BY order_list
+                                    { $$.val = &tree.Ftop{
+                                        N:     $2.exprStatement(),
+                                        Order: $5.orderByStatement(),
+                                      }
+                                    }
+            | ORDER BY order_list TOP a_expr
+                                    { $$.val = &tree.Top{
+                                        N:     $5.exprStatement(),
+                                        Order: $3.orderByStatement(),
+                                      }
+                                    }
+            | ORDER BY order_list TOP a_expr RERANK a_expr
+                                    { $$.val = &tree.Top{
+                                        N:     $5.exprStatement(),
+                                        R:     $7.exprStatement(),
+                                        Order: $3.orderByStatement(),
+                                      }
+                                    }
+            | ORDER BY order_list FTOP a_expr
+                                    { $$.val = &tree.Ftop{
+                                        N:     $5.exprStatement(),
+                                        Order: $3.orderByStatement(),
+                                      }
+                                    }
 
 order_list: order { $$.val = tree.OrderBy{$1.orderStatement()}}
             | order_list ',' order  { $$.val = append($1.orderByStatement(), $3.orderStatement())}
@@ -403,6 +587,7 @@ offset_clause: OFFSET a_expr                { $$.val = &tree.Limit{Offset: $2.ex
              | OFFSET d_expr row_or_rows    { $$.val = &tree.Limit{Offset: $2.exprStatement()} }
 
 opt_select_fetch_first_value: signed_iconst     { $$.val = $1.exprStatement() }
+                            | PLACEHOLDER       { $$.val = $1.exprStatement() }
                             | '(' a_expr ')'    { $$.val = $2.exprStatement() }
                             |                   { $$.val = &tree.Value{value.NewInt(1)} }
 
@@ -564,6 +749,10 @@ c_expr: b_expr '<' b_expr                           { $$.val = &tree.LtExpr{Left
       | b_expr NOT_EQUALS b_expr                    { $$.val = &tree.NeExpr{Left: $1.exprStatement(), Right: $3.exprStatement()} }
       | b_expr BETWEEN b_expr AND b_expr            { $$.val = &tree.BetweenExpr{E: $1.exprStatement(), From: $3.exprStatement(), To: $5.exprStatement()} }
       | b_expr NOT_LA BETWEEN b_expr AND b_expr     { $$.val = &tree.NotBetweenExpr{E: $1.exprStatement(), From: $4.exprStatement(), To: $6.exprStatement()} }
+      | b_expr IN subquery                          { $$.val = &tree.InExpr{Left: $1.exprStatement(), Right: $3.subqueryStatement()} }
+      | b_expr NOT_LA IN subquery                   { $$.val = &tree.NotInExpr{Left: $1.exprStatement(), Right: $4.subqueryStatement()} }
+      | b_expr IN '(' in_list ')'                   { $$.val = &tree.InExpr{Left: $1.exprStatement(), Right: $4.exprStatements()} }
+      | b_expr NOT_LA IN '(' in_list ')'            { $$.val = &tree.NotInExpr{Left: $1.exprStatement(), Right: $5.exprStatements()} }
       | EXISTS subquery                             {
                                                         $$.val = $2.subqueryStatement()
                                                         $$.val.(*tree.Subquery).Exists = true
@@ -572,10 +761,55 @@ c_expr: b_expr '<' b_expr                           { $$.val = &tree.LtExpr{Left
 d_expr: ICONST          { $$.val = $1.valueStatement() }
       | FCONST          { $$.val = $1.valueStatement() }
       | SCONST          { $$.val = $1.valueStatement() }
+      | PLACEHOLDER     { $$.val = $1.exprStatement() }
       | TRUE            { $$.val = &tree.Value{&value.ConstTrue} }
       | FALSE           { $$.val = &tree.Value{&value.ConstFalse} }
       | NULL            { $$.val = &tree.Value{value.ConstNull} }
       | '(' a_expr ')'  { $$.val = &tree.ParenExpr{$2.exprStatement()} }
+      | case_expr       { $$.val = $1.exprStatement() }
+      | INTERVAL signed_iconst IDENT
+                        { $$.val = &tree.IntervalExpr{N: $2.valueStatement(), Unit: $3} }
+      | '[' vector_list ']'
+                        { $$.val = &tree.Value{value.NewVector($2.float32s())} }
+      | '[' ']'         { $$.val = &tree.Value{value.NewVector([]float32{})} }
+
+vector_list: ICONST                     { $$.val = []float32{$1.float32()} }
+           | FCONST                     { $$.val = []float32{$1.float32()} }
+           | '-' ICONST                 { $$.val = []float32{-$2.float32()} }
+           | '-' FCONST                 { $$.val = []float32{-$2.float32()} }
+           | vector_list ',' ICONST     { $$.val = append($1.float32s(), $3.float32()) }
+           | vector_list ',' FCONST     { $$.val = append($1.float32s(), $3.float32()) }
+           | vector_list ',' '-' ICONST { $$.val = append($1.float32s(), -$4.float32()) }
+           | vector_list ',' '-' FCONST { $$.val = append($1.float32s(), -$4.float32()) }
+
+case_expr: CASE case_arg when_clause_list case_default END
+           {
+                $$.val = &tree.CaseExpr{E: $2.optExprStatement(), Whens: $3.whens(), Else: $4.optExprStatement()}
+           }
+
+case_arg: a_expr        { $$.val = $1.exprStatement() }
+        |               { $$.val = nil }
+
+when_clause_list: when_clause                   { $$.val = []*tree.When{$1.when()} }
+                | when_clause_list when_clause  { $$.val = append($1.whens(), $2.when()) }
+
+when_clause: WHEN a_expr THEN a_expr    { $$.val = &tree.When{Cond: $2.exprStatement(), Val: $4.exprStatement()} }
+
+case_default: ELSE a_expr   { $$.val = $2.exprStatement() }
+            |               { $$.val = nil }
+
+in_list: in_value                 { $$.val = tree.ExprStatements{$1.exprStatement()} }
+       | in_list ',' in_value     { $$.val = append($1.exprStatements(), $3.exprStatement()) }
+
+in_value: ICONST          { $$.val = $1.valueStatement() }
+        | FCONST          { $$.val = $1.valueStatement() }
+        | SCONST          { $$.val = $1.valueStatement() }
+        | PLACEHOLDER     { $$.val = $1.exprStatement() }
+        | TRUE            { $$.val = &tree.Value{&value.ConstTrue} }
+        | FALSE           { $$.val = &tree.Value{&value.ConstFalse} }
+        | NULL            { $$.val = &tree.Value{value.ConstNull} }
+        | '-' ICONST      { $$.val = $2.negative() }
+        | '-' FCONST      { $$.val = $2.negative() }
 
 signed_iconst: ICONST       { $$.val = $1.valueStatement() }
              | '+' ICONST   { $$.val = $2.valueStatement() }
@@ -600,6 +834,10 @@ func_application: func_name '(' ')'
                   {
                     $$.val = &tree.FuncExpr{Name: $1, Es: $3.exprStatements() }
                   }
+                | func_name '(' '*' ')'
+                  {
+                    $$.val = &tree.FuncExpr{Name: $1, Es: tree.ExprStatements{&tree.StarExpr{}} }
+                  }
 
 func_expr_common_subexpr: CAST '(' a_expr AS cast_target ')'
                           {
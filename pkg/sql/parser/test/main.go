@@ -8,6 +8,6 @@ import (
 )
 
 func main() {
-	stmt, err := parser.Parse(os.Args[1])
+	stmt, err := parser.ParseStatement(os.Args[1])
 	fmt.Printf("%v: %v\n", stmt, err)
 }
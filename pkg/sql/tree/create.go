@@ -0,0 +1,78 @@
+package tree
+
+import "fmt"
+
+// CreateTable is the statement CREATE TABLE Table (Defs) WITH (Options).
+type CreateTable struct {
+	Table   *TableName
+	Defs    TableDefs
+	Options Options
+}
+
+// TableDef is the definition of column or index.
+type TableDef interface {
+	Statement
+	tableDef()
+}
+
+func (*ColumnDef) tableDef() {}
+func (*IndexDef) tableDef()  {}
+
+type TableDefs []TableDef
+
+// ColumnDef is the definition of column, Type is the name of type such as
+// UInt64 or Vector(512).
+type ColumnDef struct {
+	Name Name
+	Type string
+}
+
+// IndexDef declares the indexed columns.
+type IndexDef struct {
+	Cols NameList
+}
+
+type Options []*Option
+
+type Option struct {
+	Name Name
+	E    *Value
+}
+
+func (n *CreateTable) String() string {
+	var s string
+
+	s += fmt.Sprintf("CREATE TABLE %s (%s)", n.Table, n.Defs)
+	if len(n.Options) > 0 {
+		s += fmt.Sprintf(" WITH (%s)", n.Options)
+	}
+	return s
+}
+
+func (n *ColumnDef) String() string { return fmt.Sprintf("%s %s", n.Name, n.Type) }
+func (n *IndexDef) String() string  { return fmt.Sprintf("INDEX (%s)", n.Cols) }
+func (n *Option) String() string    { return fmt.Sprintf("%s = %s", n.Name, n.E) }
+
+func (ns TableDefs) String() string {
+	var s string
+
+	for i := range ns {
+		if i > 0 {
+			s += ", "
+		}
+		s += ns[i].String()
+	}
+	return s
+}
+
+func (ns Options) String() string {
+	var s string
+
+	for i := range ns {
+		if i > 0 {
+			s += ", "
+		}
+		s += ns[i].String()
+	}
+	return s
+}
@@ -15,6 +15,8 @@ func (*Index) exprStatement() {}
 
 func (*Value) exprStatement() {}
 
+func (*Placeholder) exprStatement() {}
+
 func (*OrExpr) exprStatement()  {}
 func (*AndExpr) exprStatement() {}
 func (*NotExpr) exprStatement() {}
@@ -39,12 +41,18 @@ func (*Subquery) exprStatement() {}
 func (*BetweenExpr) exprStatement()    {}
 func (*NotBetweenExpr) exprStatement() {}
 
+func (*InExpr) exprStatement()    {}
+func (*NotInExpr) exprStatement() {}
+
 func (*IsNullExpr) exprStatement()    {}
 func (*IsNotNullExpr) exprStatement() {}
 
 func (*ParenExpr) exprStatement() {}
 
-func (*FuncExpr) exprStatement() {}
+func (*CaseExpr) exprStatement()     {}
+func (*IntervalExpr) exprStatement() {}
+func (*FuncExpr) exprStatement()     {}
+func (*StarExpr) exprStatement()     {}
 
 func (ExprStatements) exprStatement() {}
 
@@ -53,6 +61,8 @@ func (ColunmNameList) exprStatement() {}
 
 func (e *Value) String() string { return e.E.String() }
 
+func (e *Placeholder) String() string { return fmt.Sprintf("$%v", e.Idx+1) }
+
 func (e *NotExpr) String() string { return fmt.Sprintf("NOT %s", e.E) }
 func (e *OrExpr) String() string  { return fmt.Sprintf("%s OR %s", e.Left, e.Right) }
 func (e *AndExpr) String() string { return fmt.Sprintf("%s AND %s", e.Left, e.Right) }
@@ -80,11 +90,40 @@ func (e *NotBetweenExpr) String() string {
 	return fmt.Sprintf("%s NOT BETWEEN %s AND %s", e.E, e.From, e.To)
 }
 
+func (e *InExpr) String() string    { return fmt.Sprintf("%s IN %s", e.Left, inList(e.Right)) }
+func (e *NotInExpr) String() string { return fmt.Sprintf("%s NOT IN %s", e.Left, inList(e.Right)) }
+
+// inList returns the subquery or the parenthesized list of values.
+func inList(n ExprStatement) string {
+	if es, ok := n.(ExprStatements); ok {
+		return "(" + es.String() + ")"
+	}
+	return n.String()
+}
+
 func (e *IsNullExpr) String() string    { return fmt.Sprintf("%s IS NULL", e.E) }
 func (e *IsNotNullExpr) String() string { return fmt.Sprintf("%s IS NOT NULL", e.E) }
 
 func (e *ParenExpr) String() string { return fmt.Sprintf("(%s)", e.E) }
 
+func (e *StarExpr) String() string { return "*" }
+
+func (e *CaseExpr) String() string {
+	s := "CASE"
+	if e.E != nil {
+		s += " " + e.E.String()
+	}
+	for _, w := range e.Whens {
+		s += fmt.Sprintf(" WHEN %s THEN %s", w.Cond, w.Val)
+	}
+	if e.Else != nil {
+		s += fmt.Sprintf(" ELSE %s", e.Else)
+	}
+	return s + " END"
+}
+
+func (e *IntervalExpr) String() string { return fmt.Sprintf("INTERVAL %s %s", e.N, e.Unit) }
+
 func (e *FuncExpr) String() string {
 	return fmt.Sprintf("%s(%s)", e.Name, e.Es)
 }
@@ -110,6 +149,11 @@ type Value struct {
 	E value.Value
 }
 
+// Placeholder is a parameter of prepared statement, Idx starts from 0.
+type Placeholder struct {
+	Idx int
+}
+
 type NotExpr struct {
 	E ExprStatement
 }
@@ -180,6 +224,16 @@ type NotBetweenExpr struct {
 	From, To ExprStatement
 }
 
+// InExpr represents 'Left IN Right', Right is a subquery or the
+// ExprStatements of a list of constants.
+type InExpr struct {
+	Left, Right ExprStatement
+}
+
+type NotInExpr struct {
+	Left, Right ExprStatement
+}
+
 type IsNullExpr struct {
 	E ExprStatement
 }
@@ -192,9 +246,30 @@ type ParenExpr struct {
 	E ExprStatement
 }
 
+// CaseExpr represents 'CASE [E] WHEN ... THEN ... [ELSE Else] END', the
+// conditions are compared with E if E is not nil.
+type CaseExpr struct {
+	E     ExprStatement
+	Whens []*When
+	Else  ExprStatement
+}
+
+type When struct {
+	Cond, Val ExprStatement
+}
+
+// IntervalExpr represents 'INTERVAL N Unit', such as 'INTERVAL 7 DAY'.
+type IntervalExpr struct {
+	N    *Value
+	Unit string
+}
+
 type FuncExpr struct {
 	Name string
 	Es   ExprStatements
 }
 
+// StarExpr represents the argument '*' of function, such as count(*).
+type StarExpr struct{}
+
 type ExprStatements []ExprStatement
@@ -0,0 +1,31 @@
+package tree
+
+// Insert is the statement INSERT INTO Table (Columns) VALUES ... or
+// INSERT INTO Table (Columns) SELECT ..., the columns are the attributes
+// of table if Columns is empty.
+type Insert struct {
+	Table   *TableName
+	Columns NameList
+	Values  []ExprStatements // rows of VALUES
+	Select  *Select          // rows of SELECT
+}
+
+func (n *Insert) String() string {
+	var s string
+
+	s += "INSERT INTO " + n.Table.String()
+	if len(n.Columns) > 0 {
+		s += " (" + n.Columns.String() + ")"
+	}
+	if n.Select != nil {
+		return s + " " + n.Select.String()
+	}
+	s += " VALUES"
+	for i, row := range n.Values {
+		if i > 0 {
+			s += ","
+		}
+		s += " (" + row.String() + ")"
+	}
+	return s
+}
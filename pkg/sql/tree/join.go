@@ -73,3 +73,14 @@ func (n *JoinClause) String() string {
 	}
 	return s
 }
+
+// JoinTable represents the tables joined by the columns of Using.
+type JoinTable struct {
+	Type        JoinType
+	Using       NameList
+	Left, Right TableStatement
+}
+
+func (n *JoinTable) String() string {
+	return fmt.Sprintf("%s %s %s USING (%s)", n.Left, n.Type, n.Right, n.Using)
+}
@@ -12,19 +12,37 @@ func (*Ftop) orderStatement()   {}
 func (OrderBy) orderStatement() {}
 
 type Top struct {
-	N ExprStatement
+	N     ExprStatement
+	R     ExprStatement // oversampling factor of rerank
+	Order OrderBy       // ordering of the top candidates
 }
 
 type Ftop struct {
-	N ExprStatement
+	N     ExprStatement
+	Order OrderBy // ordering of the top candidates
 }
 
 func (t *Top) String() string {
-	return fmt.Sprintf("TOP %s", t.N.String())
+	var s string
+
+	s += fmt.Sprintf("TOP %s", t.N.String())
+	if t.R != nil {
+		s += fmt.Sprintf(" RERANK %s", t.R.String())
+	}
+	if len(t.Order) > 0 {
+		s += " " + t.Order.String()
+	}
+	return s
 }
 
 func (t *Ftop) String() string {
-	return fmt.Sprintf("FTOP %s", t.N.String())
+	var s string
+
+	s += fmt.Sprintf("FTOP %s", t.N.String())
+	if len(t.Order) > 0 {
+		s += " " + t.Order.String()
+	}
+	return s
 }
 
 type OrderBy []*Order
@@ -62,7 +80,7 @@ func (n *Order) String() string {
 
 	s += n.E.String()
 	if n.Type != DefaultDirection {
-		s = " " + n.Type.String()
+		s += " " + n.Type.String()
 	}
 	return s
 }
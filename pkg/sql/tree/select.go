@@ -30,6 +30,9 @@ func (n *Limit) String() string {
 		s += "LIMIT " + n.Count.String()
 	}
 	if n.Offset != nil {
+		if len(s) > 0 {
+			s += " "
+		}
 		s += "OFFSET " + n.Offset.String()
 	}
 	return s
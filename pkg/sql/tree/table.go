@@ -8,6 +8,7 @@ type TableStatement interface {
 func (*Subquery) tableStatement()     {}
 func (*TableName) tableStatement()    {}
 func (*AliasedTable) tableStatement() {}
+func (*JoinTable) tableStatement()    {}
 
 type AliasedTable struct {
 	As  *AliasClause
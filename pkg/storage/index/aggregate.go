@@ -0,0 +1,150 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/bsi"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/pilosa/pilosa/roaring"
+)
+
+// Values returns the values of the indexed string attribute.
+func (r *index) Values(attr string) ([]string, error) {
+	var vs []string
+
+	prefix := bsKey(r.id, attr, "")
+	it, err := r.db.NewIterator([]byte(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	if err := it.Seek([]byte(prefix)); err != nil {
+		return nil, err
+	}
+	for ; it.Valid(); it.Next() {
+		vs = append(vs, string(it.Key()[len(prefix):]))
+	}
+	return vs, nil
+}
+
+// Aggregate returns the aggregation of the numeric attribute over the
+// rows of mp, nil mp means all rows.
+func (r *index) Aggregate(op int, attr string, mp *roaring.Bitmap) (value.Value, error) {
+	a, err := r.attribute(attr)
+	if err != nil {
+		return nil, err
+	}
+	var b bsi.Bsi
+
+	switch a.Type {
+	case types.T_uint8, types.T_uint16, types.T_uint32, types.T_uint64:
+		b, err = getUbsi(ubsiKey(r.id, attr), r.db, r.lc)
+	case types.T_int8, types.T_int16, types.T_int32, types.T_int64, types.T_float32, types.T_float64, types.T_timestamp:
+		b, err = getBsi(bsiKey(r.id, attr), r.db, r.lc)
+	default:
+		return nil, fmt.Errorf("unsupport type '%s' for aggregation", types.T(a.Type))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		if op == Count {
+			return value.NewUint64(0), nil
+		}
+		return value.ConstNull, nil
+	}
+	switch op {
+	case Count:
+		return value.NewUint64(b.Count(mp)), nil
+	case Sum:
+		v, cnt := b.Sum(mp)
+		if cnt == 0 {
+			return value.ConstNull, nil
+		}
+		return aggValue(a.Type, v), nil
+	case Min:
+		v, cnt := b.Min(mp)
+		if cnt == 0 {
+			return value.ConstNull, nil
+		}
+		return aggValue(a.Type, v), nil
+	case Max:
+		v, cnt := b.Max(mp)
+		if cnt == 0 {
+			return value.ConstNull, nil
+		}
+		return aggValue(a.Type, v), nil
+	case Avg:
+		v, cnt := b.Sum(mp)
+		if cnt == 0 {
+			return value.ConstNull, nil
+		}
+		return value.NewFloat64(toFloat64(a.Type, v) / float64(cnt)), nil
+	}
+	return nil, fmt.Errorf("unsupport aggregation '%v'", op)
+}
+
+func (r *index) attribute(attr string) (metadata.Attribute, error) {
+	for _, a := range r.attrs {
+		if a.Name == attr {
+			return a, nil
+		}
+	}
+	return metadata.Attribute{}, fmt.Errorf("attribute '%s' not exist", attr)
+}
+
+// aggValue converts the result of bsi to the value of typ, the integers
+// are widened to 64 bits.
+func aggValue(typ uint32, v interface{}) value.Value {
+	switch typ {
+	case types.T_uint8, types.T_uint16, types.T_uint32, types.T_uint64:
+		return value.NewUint64(toUint64(v))
+	case types.T_float32:
+		return value.NewFloat64(float64(toInt64(v)) / Ffrac)
+	case types.T_float64:
+		return value.NewFloat64(float64(toInt64(v)) / Dfrac)
+	case types.T_timestamp:
+		r := value.Timestamp(toInt64(v))
+		return &r
+	}
+	return value.NewInt64(toInt64(v))
+}
+
+// toFloat64 returns the sum of bsi as float64 without the fraction.
+func toFloat64(typ uint32, v interface{}) float64 {
+	switch typ {
+	case types.T_uint8, types.T_uint16, types.T_uint32, types.T_uint64:
+		return float64(toUint64(v))
+	case types.T_float32:
+		return float64(toInt64(v)) / Ffrac
+	case types.T_float64:
+		return float64(toInt64(v)) / Dfrac
+	}
+	return float64(toInt64(v))
+}
+
+func toInt64(v interface{}) int64 {
+	switch x := v.(type) {
+	case int64:
+		return x
+	case uint64:
+		return int64(x)
+	case int:
+		return int64(x)
+	}
+	return 0
+}
+
+func toUint64(v interface{}) uint64 {
+	switch x := v.(type) {
+	case uint64:
+		return x
+	case int64:
+		return uint64(x)
+	case int:
+		return uint64(x)
+	}
+	return 0
+}
@@ -3,6 +3,7 @@ package index
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 
 	"github.com/deepfabric/thinkkv/pkg/engine"
 	"github.com/deepfabric/vectorsql/pkg/bsi"
@@ -94,7 +95,14 @@ func (r *index) Eq(attr string, v value.Value) (*roaring.Bitmap, error) {
 		}
 		return mp.Eq(value.MustBeTimestamp(v).Unix())
 	case types.T_string:
-		return getBitmap(bsKey(r.id, attr, value.MustBeString(v)), r.db, r.lc)
+		mp, err := getBitmap(bsKey(r.id, attr, value.MustBeString(v)), r.db, r.lc)
+		if err != nil {
+			return nil, err
+		}
+		if mp == nil { // no row has the value
+			return roaring.NewBitmap(), nil
+		}
+		return mp, nil
 	}
 	return nil, fmt.Errorf("unsupport type '%s' for Eq", v.ResolvedType())
 }
@@ -459,13 +467,55 @@ func (r *index) Ge(attr string, v value.Value) (*roaring.Bitmap, error) {
 	return nil, fmt.Errorf("unsupport type '%s' for Ge", v.ResolvedType())
 }
 
-func (r *index) AddTuples(ts []interface{}) error {
+// IsNull returns the rows whose attribute attr is null.
+func (r *index) IsNull(attr string) (*roaring.Bitmap, error) {
+	mp, err := getBitmap(nullKey(r.id, attr), r.db, r.lc)
+	if err != nil {
+		return nil, err
+	}
+	if mp == nil {
+		return roaring.NewBitmap(), nil
+	}
+	return mp, nil
+}
+
+// IsNotNull returns the rows whose attribute attr is not null, they are
+// the rows of relation except the null ones.
+func (r *index) IsNotNull(attr string) (*roaring.Bitmap, error) {
+	mp, err := getUbsi(ubsiKey(r.id, r.attrs[0].Name), r.db, r.lc)
+	if err != nil {
+		return nil, err
+	}
+	if mp == nil {
+		return roaring.NewBitmap(), nil
+	}
+	np, err := r.IsNull(attr)
+	if err != nil {
+		return nil, err
+	}
+	return mp.Map().Difference(np), nil
+}
+
+// AddTuples adds the tuples ts, nps[i] is the rows whose i-th attribute
+// is null, the null rows are recorded by the null bitmap of attribute
+// instead of its index.
+func (r *index) AddTuples(ts []interface{}, nps []*roaring.Bitmap) error {
 	var seqs []uint64
 
 	seqs = ts[0].([]uint64)
 	smp := make(map[string]bsi.Bsi)
 	bmp := make(map[string]*roaring.Bitmap)
 	for i, j := 0, len(ts); i < j; i++ {
+		if i < len(nps) && nps[i] != nil && r.attrs[i].Nullable {
+			if err := r.addNulls(seqs, r.attrs[i], nps[i], smp, bmp); err != nil {
+				return err
+			}
+			vs, t := removeNulls(seqs, ts[i], nps[i])
+			if err := r.addTuple(vs, r.attrs[i], t, smp, bmp); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := r.addTuple(seqs, r.attrs[i], ts[i], smp, bmp); err != nil {
 			return err
 		}
@@ -491,6 +541,65 @@ func (r *index) AddTuples(ts []interface{}) error {
 	return nil
 }
 
+// addNulls records the null rows np of attribute attr, the rows of seqs
+// which are not null any more are removed from the null bitmap and the
+// old values of null rows are removed from the bsi.
+func (r *index) addNulls(seqs []uint64, attr metadata.Attribute, np *roaring.Bitmap, smp map[string]bsi.Bsi, bmp map[string]*roaring.Bitmap) error {
+	switch attr.Type {
+	case types.T_vector:
+		return nil
+	case types.T_string:
+		if !attr.Index {
+			return nil
+		}
+	default:
+		var err error
+		var mp bsi.Bsi
+
+		k := bsiKey(r.id, attr.Name)
+		switch attr.Type {
+		case types.T_uint8, types.T_uint16, types.T_uint32, types.T_uint64:
+			k = ubsiKey(r.id, attr.Name)
+			if mp = smp[k]; mp == nil {
+				mp, err = getUbsi(k, r.db, r.lc)
+			}
+		default:
+			if mp = smp[k]; mp == nil {
+				mp, err = getBsi(k, r.db, r.lc)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if mp != nil {
+			for _, seq := range np.Slice() {
+				mp.Del(seq)
+			}
+			smp[k] = mp
+		}
+	}
+	k := nullKey(r.id, attr.Name)
+	mp, ok := bmp[k]
+	if !ok {
+		var err error
+		if mp, err = getBitmap(k, r.db, r.lc); err != nil {
+			return err
+		}
+		if mp == nil {
+			mp = roaring.NewBitmap()
+		}
+		bmp[k] = mp
+	}
+	for _, seq := range seqs {
+		if np.Contains(seq) {
+			mp.Add(seq)
+		} else {
+			mp.Remove(seq)
+		}
+	}
+	return nil
+}
+
 func (r *index) addTuple(seqs []uint64, attr metadata.Attribute, t interface{}, smp map[string]bsi.Bsi, bmp map[string]*roaring.Bitmap) error {
 	switch attr.Type {
 	case types.T_string:
@@ -873,10 +982,37 @@ func bsKey(id, attr string, v string) string {
 	return buf.String()
 }
 
+func nullKey(id, attr string) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(id)
+	buf.WriteByte('.')
+	buf.WriteString(attr)
+	buf.WriteString(".N")
+	return buf.String()
+}
+
+// removeNulls returns the rows of seqs and the values of slice t which
+// are not null.
+func removeNulls(seqs []uint64, t interface{}, np *roaring.Bitmap) ([]uint64, interface{}) {
+	vs := reflect.ValueOf(t)
+	rs := make([]uint64, 0, len(seqs))
+	ts := reflect.MakeSlice(vs.Type(), 0, vs.Len())
+	for i, seq := range seqs {
+		if !np.Contains(seq) {
+			rs = append(rs, seq)
+			ts = reflect.Append(ts, vs.Index(i))
+		}
+	}
+	return rs, ts.Interface()
+}
+
+// show writes a copy of mp, since the containers emptied by removal are
+// counted but not written by roaring, which cannot be read back.
 func show(mp *roaring.Bitmap) ([]byte, error) {
 	var buf bytes.Buffer
 
-	if _, err := mp.WriteTo(&buf); err != nil {
+	if _, err := roaring.NewBitmap().Union(mp).WriteTo(&buf); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
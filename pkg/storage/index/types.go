@@ -13,8 +13,20 @@ const (
 	Dfrac = 100000 // double fraction
 )
 
+// aggregate functions
+const (
+	Count = iota
+	Sum
+	Min
+	Max
+	Avg
+)
+
 type Index interface {
-	AddTuples([]interface{}) error
+	AddTuples([]interface{}, []*roaring.Bitmap) error
+
+	Values(string) ([]string, error)
+	Aggregate(int, string, *roaring.Bitmap) (value.Value, error)
 
 	Eq(string, value.Value) (*roaring.Bitmap, error)
 	Ne(string, value.Value) (*roaring.Bitmap, error)
@@ -22,11 +34,15 @@ type Index interface {
 	Le(string, value.Value) (*roaring.Bitmap, error)
 	Gt(string, value.Value) (*roaring.Bitmap, error)
 	Ge(string, value.Value) (*roaring.Bitmap, error)
+
+	IsNull(string) (*roaring.Bitmap, error)
+	IsNotNull(string) (*roaring.Bitmap, error)
 }
 
 // id.attr's name.v 		-> bitmap -- string
 // id.attr's name.I       	-> bitmap -- bsi, bitmap
 // id.attr's name.U      	-> bitmap -- ubsi bitmap
+// id.attr's name.N      	-> bitmap -- null bitmap
 type index struct {
 	isE   bool
 	id    string // uid.database.table
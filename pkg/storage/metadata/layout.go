@@ -0,0 +1,44 @@
+package metadata
+
+import "fmt"
+
+var DefaultLayout = Layout{UidBits: 30, PidBits: 34}
+
+// XidLayout returns the bit layout of xid, the metadata created before
+// the layout is configurable uses the default layout.
+func (md Metadata) XidLayout() Layout {
+	if md.Layout == (Layout{}) {
+		return DefaultLayout
+	}
+	return md.Layout
+}
+
+func (l Layout) String() string {
+	return fmt.Sprintf("uid(%v bits) + pid(%v bits)", l.UidBits, l.PidBits)
+}
+
+func (l Layout) Validate() error {
+	if l.UidBits < 1 || l.UidBits > 32 {
+		return fmt.Errorf("illegal layout '%s': uid must be 1 to 32 bits", l)
+	}
+	if l.PidBits < 0 || l.UidBits+l.PidBits > 64 {
+		return fmt.Errorf("illegal layout '%s': xid must be at most 64 bits", l)
+	}
+	return nil
+}
+
+// Uid returns the uid which xid belongs to.
+func (l Layout) Uid(xid int64) uint32 {
+	return uint32(uint64(xid) >> uint(l.PidBits))
+}
+
+// Check checks that xid maps back to uid.
+func (l Layout) Check(uid uint64, xid int64) error {
+	if uid>>uint(l.UidBits) != 0 {
+		return fmt.Errorf("uid '%v' out of range of layout '%s'", uid, l)
+	}
+	if uint64(xid)>>uint(l.PidBits) != uid {
+		return fmt.Errorf("xid '%v' maps to uid '%v' instead of '%v' with layout '%s'", uint64(xid), l.Uid(xid), uid, l)
+	}
+	return nil
+}
@@ -20,6 +20,12 @@ func init() {
 }
 
 func (a Attribute) String() string {
+	if a.Type == types.T_vector {
+		return fmt.Sprintf("%s(%s(%v))", a.Name, types.T(a.Type), a.Dim)
+	}
+	if a.Nullable {
+		return fmt.Sprintf("%s(NULLABLE(%s))", a.Name, types.T(a.Type))
+	}
 	return fmt.Sprintf("%s(%s)", a.Name, types.T(a.Type))
 }
 
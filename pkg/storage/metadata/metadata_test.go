@@ -14,10 +14,11 @@ func TestMetadata(t *testing.T) {
 	var as []Attribute
 
 	{
-		as = append(as, Attribute{true, types.T_uint8, "age"})
-		as = append(as, Attribute{false, types.T_string, "name"})
+		as = append(as, Attribute{true, types.T_uint8, "age", 0, true})
+		as = append(as, Attribute{false, types.T_string, "name", 0, false})
+		as = append(as, Attribute{false, types.T_vector, "face", 512, false})
 	}
-	md := Metadata{true, as}
+	md := Metadata{true, as, DefaultLayout}
 	data, err := encoding.Encode(md)
 	if err != nil {
 		log.Fatal(err)
@@ -32,3 +33,25 @@ func TestMetadata(t *testing.T) {
 		fmt.Printf("tm: %v\n", tm)
 	}
 }
+
+func TestLayout(t *testing.T) {
+	l := Layout{UidBits: 20, PidBits: 8}
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if uid := l.Uid(3<<8 | 7); uid != 3 {
+		t.Fatalf("uid = %v, want 3", uid)
+	}
+	if err := l.Check(3, 3<<8|7); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Check(3, 4<<8|7); err == nil {
+		t.Fatal("xid of another uid is accepted")
+	}
+	if err := l.Check(1<<20, 1<<28); err == nil {
+		t.Fatal("uid out of range is accepted")
+	}
+	if err := (Layout{UidBits: 32, PidBits: 34}).Validate(); err == nil {
+		t.Fatal("layout of 66 bits is accepted")
+	}
+}
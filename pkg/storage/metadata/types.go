@@ -1,12 +1,21 @@
 package metadata
 
 type Attribute struct {
-	Index bool
-	Type  uint32 // type of attribute
-	Name  string // name of attribute
+	Index    bool
+	Type     uint32 // type of attribute
+	Name     string // name of attribute
+	Dim      int    // dimension of vector
+	Nullable bool   // values of attribute may be null
+}
+
+// Layout is the bit layout of xid, xid = uid<<PidBits | pid
+type Layout struct {
+	UidBits int // number of bits of uid
+	PidBits int // number of bits of sub-id of vector
 }
 
 type Metadata struct {
-	IsE   bool
-	Attrs []Attribute
+	IsE    bool
+	Attrs  []Attribute
+	Layout Layout // zero means DefaultLayout
 }
@@ -90,11 +90,23 @@ func (r *relation) Metadata() metadata.Metadata {
 	return r.md
 }
 
-func (r *relation) AddTuples(ts []interface{}) error {
+func (r *relation) AddTuples(ts []interface{}, nps []*roaring.Bitmap) error {
 	r.Lock()
 	defer r.Unlock()
 	defer r.db.Sync()
-	return r.idx.AddTuples(ts)
+	return r.idx.AddTuples(ts, nps)
+}
+
+func (r *relation) Values(attr string) ([]string, error) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.idx.Values(attr)
+}
+
+func (r *relation) Aggregate(op int, attr string, mp *roaring.Bitmap) (value.Value, error) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.idx.Aggregate(op, attr, mp)
 }
 
 func (r *relation) Eq(attr string, v value.Value) (*roaring.Bitmap, error) {
@@ -132,3 +144,15 @@ func (r *relation) Ge(attr string, v value.Value) (*roaring.Bitmap, error) {
 	defer r.RUnlock()
 	return r.idx.Ge(attr, v)
 }
+
+func (r *relation) IsNull(attr string) (*roaring.Bitmap, error) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.idx.IsNull(attr)
+}
+
+func (r *relation) IsNotNull(attr string) (*roaring.Bitmap, error) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.idx.IsNotNull(attr)
+}
@@ -25,7 +25,10 @@ type Relation interface {
 
 	Metadata() metadata.Metadata
 
-	AddTuples([]interface{}) error
+	AddTuples([]interface{}, []*roaring.Bitmap) error
+
+	Values(string) ([]string, error)
+	Aggregate(int, string, *roaring.Bitmap) (value.Value, error)
 
 	Eq(string, value.Value) (*roaring.Bitmap, error)
 	Ne(string, value.Value) (*roaring.Bitmap, error)
@@ -33,6 +36,9 @@ type Relation interface {
 	Le(string, value.Value) (*roaring.Bitmap, error)
 	Gt(string, value.Value) (*roaring.Bitmap, error)
 	Ge(string, value.Value) (*roaring.Bitmap, error)
+
+	IsNull(string) (*roaring.Bitmap, error)
+	IsNotNull(string) (*roaring.Bitmap, error)
 }
 
 type storage struct {
@@ -0,0 +1,35 @@
+package vectors
+
+import (
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/thinkkv/pkg/engine"
+)
+
+const (
+	vprefix = "_V." // vector
+	tprefix = "_T." // tombstone
+	rprefix = "_R." // replaced uid
+)
+
+// state of tombstone
+const (
+	Removed  = 1 // the vector is removed
+	Replaced = 2 // the vector is replaced, the vector server may still keep the old one
+)
+
+type Vectors interface {
+	Add([]float32, []uint32, []int64) error
+	Get([]uint32, []int64) ([][]float32, error)
+	Remove([]uint32, []int64) error
+	Replace([]uint32) error
+	Tombstones([]uint32, []int64) ([]byte, error)
+	Vectors(*roaring.Bitmap) ([]int64, [][]float32, error)
+}
+
+// _V.uid.xid -> vector
+// _T.xid -> state of tombstone
+// _R.uid -> the vectors of uid are replaced
+type vectors struct {
+	dim int
+	db  engine.DB
+}
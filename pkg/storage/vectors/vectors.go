@@ -0,0 +1,218 @@
+package vectors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/thinkkv/pkg/engine"
+)
+
+func New(dim int, db engine.DB) *vectors {
+	return &vectors{dim, db}
+}
+
+func (vs *vectors) Add(xbs []float32, uids []uint32, xids []int64) error {
+	if len(xbs) != len(xids)*vs.dim {
+		return fmt.Errorf("%v vectors for %v xids", len(xbs)/vs.dim, len(xids))
+	}
+	bat, err := vs.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	for i, xid := range xids {
+		ok, err := vs.exist(uids[i], xid)
+		if err != nil {
+			bat.Cancel()
+			return err
+		}
+		if ok { // the vector server keeps the old vector of xid
+			if err := bat.Set(tkey(xid), []byte{Replaced}); err != nil {
+				bat.Cancel()
+				return err
+			}
+		}
+		if err := bat.Set(vkey(uids[i], xid), encode(xbs[i*vs.dim:(i+1)*vs.dim])); err != nil {
+			bat.Cancel()
+			return err
+		}
+	}
+	return bat.Commit()
+}
+
+// Remove removes the vectors of xids, and marks xids as removed.
+func (vs *vectors) Remove(uids []uint32, xids []int64) error {
+	bat, err := vs.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	for i, xid := range xids {
+		if err := bat.Del(vkey(uids[i], xid)); err != nil {
+			bat.Cancel()
+			return err
+		}
+		if err := bat.Set(tkey(xid), []byte{Removed}); err != nil {
+			bat.Cancel()
+			return err
+		}
+	}
+	return bat.Commit()
+}
+
+// Replace marks the vectors of uids as replaced, the vector server
+// may still keep the vectors of these uids which are added before the
+// vectors are stored locally, and such vectors have no tombstones.
+func (vs *vectors) Replace(uids []uint32) error {
+	bat, err := vs.db.NewBatch()
+	if err != nil {
+		return err
+	}
+	for _, uid := range uids {
+		if err := bat.Set(rkey(uid), []byte{Replaced}); err != nil {
+			bat.Cancel()
+			return err
+		}
+	}
+	return bat.Commit()
+}
+
+// Tombstones returns the states of tombstone of xids, 0 means that
+// xid has no tombstone. An xid of a replaced uid without tombstone is
+// removed if it has no stored vector, otherwise it is replaced.
+func (vs *vectors) Tombstones(uids []uint32, xids []int64) ([]byte, error) {
+	ts := make([]byte, len(xids))
+	for i, xid := range xids {
+		v, err := vs.db.Get(tkey(xid))
+		switch {
+		case err == nil && len(v) > 0:
+			ts[i] = v[0]
+			continue
+		case err == nil || err == engine.NotExist:
+		default:
+			return nil, err
+		}
+		switch _, err := vs.db.Get(rkey(uids[i])); {
+		case err == engine.NotExist:
+			continue
+		case err != nil:
+			return nil, err
+		}
+		switch _, err := vs.db.Get(vkey(uids[i], xid)); {
+		case err == nil:
+			ts[i] = Replaced
+		case err == engine.NotExist:
+			ts[i] = Removed
+		default:
+			return nil, err
+		}
+	}
+	return ts, nil
+}
+
+// exist returns true if xid has a stored vector or a tombstone.
+func (vs *vectors) exist(uid uint32, xid int64) (bool, error) {
+	for _, k := range [][]byte{vkey(uid, xid), tkey(xid)} {
+		switch _, err := vs.db.Get(k); {
+		case err == nil:
+			return true, nil
+		case err != engine.NotExist:
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// Get returns the stored vectors of xids, the vector is nil if xid has
+// no stored vector, such as the xids added before the vectors are
+// stored locally.
+func (vs *vectors) Get(uids []uint32, xids []int64) ([][]float32, error) {
+	xbs := make([][]float32, len(xids))
+	for i, xid := range xids {
+		v, err := vs.db.Get(vkey(uids[i], xid))
+		switch {
+		case err == nil:
+			xbs[i] = decode(v)
+		case err != engine.NotExist:
+			return nil, err
+		}
+	}
+	return xbs, nil
+}
+
+// Vectors returns all the stored vectors which belong to the uids of mp.
+func (vs *vectors) Vectors(mp *roaring.Bitmap) ([]int64, [][]float32, error) {
+	var xids []int64
+	var xbs [][]float32
+
+	itr := mp.Iterator()
+	for itr.HasNext() {
+		prefix := ukey(itr.Next())
+		it, err := vs.db.NewIterator(prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := it.Seek(prefix); err != nil {
+			it.Close()
+			return nil, nil, err
+		}
+		for ; it.Valid(); it.Next() {
+			k := it.Key()
+			v, err := it.Value()
+			if err != nil {
+				it.Close()
+				return nil, nil, err
+			}
+			xids = append(xids, int64(binary.BigEndian.Uint64(k[len(k)-8:])))
+			xbs = append(xbs, decode(v))
+		}
+		it.Close()
+	}
+	return xids, xbs, nil
+}
+
+func ukey(uid uint32) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(vprefix)
+	buf.WriteString(fmt.Sprintf("%08x.", uid))
+	return buf.Bytes()
+}
+
+func vkey(uid uint32, xid int64) []byte {
+	var v [8]byte
+
+	binary.BigEndian.PutUint64(v[:], uint64(xid))
+	return append(ukey(uid), v[:]...)
+}
+
+func tkey(xid int64) []byte {
+	var v [8]byte
+
+	binary.BigEndian.PutUint64(v[:], uint64(xid))
+	return append([]byte(tprefix), v[:]...)
+}
+
+func rkey(uid uint32) []byte {
+	var v [4]byte
+
+	binary.BigEndian.PutUint32(v[:], uid)
+	return append([]byte(rprefix), v[:]...)
+}
+
+func encode(xs []float32) []byte {
+	data := make([]byte, len(xs)*4)
+	for i, x := range xs {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(x))
+	}
+	return data
+}
+
+func decode(data []byte) []float32 {
+	xs := make([]float32, len(data)/4)
+	for i := range xs {
+		xs[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return xs
+}
@@ -0,0 +1,80 @@
+package vectors
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/thinkkv/pkg/engine/pb"
+)
+
+func TestTombstones(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vectors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	defer db.Close()
+	vs := New(2, db)
+
+	// uid 1 has xids 10, 11, uid 2 has xid 20, xid 12 of uid 1 and
+	// xid 21 of uid 2 are added before the vectors are stored locally.
+	if err := vs.Add([]float32{1, 0, 0, 1, 1, 1}, []uint32{1, 1, 2}, []int64{10, 11, 20}); err != nil {
+		t.Fatal(err)
+	}
+	uids, xids := []uint32{1, 1, 1, 2, 2}, []int64{10, 11, 12, 20, 21}
+	if ts, err := vs.Tombstones(uids, xids); err != nil {
+		t.Fatal(err)
+	} else if want := []byte{0, 0, 0, 0, 0}; !reflect.DeepEqual(ts, want) {
+		t.Fatalf("tombstones = %v, want %v", ts, want)
+	}
+	if err := vs.Add([]float32{2, 2}, []uint32{1}, []int64{10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vs.Remove([]uint32{1}, []int64{11}); err != nil {
+		t.Fatal(err)
+	}
+	if ts, err := vs.Tombstones(uids, xids); err != nil {
+		t.Fatal(err)
+	} else if want := []byte{Replaced, Removed, 0, 0, 0}; !reflect.DeepEqual(ts, want) {
+		t.Fatalf("tombstones = %v, want %v", ts, want)
+	}
+	if err := vs.Replace([]uint32{1}); err != nil {
+		t.Fatal(err)
+	}
+	if ts, err := vs.Tombstones(uids, xids); err != nil {
+		t.Fatal(err)
+	} else if want := []byte{Replaced, Removed, Removed, 0, 0}; !reflect.DeepEqual(ts, want) {
+		t.Fatalf("tombstones = %v, want %v", ts, want)
+	}
+	if err := vs.Replace([]uint32{2}); err != nil {
+		t.Fatal(err)
+	}
+	if ts, err := vs.Tombstones(uids, xids); err != nil {
+		t.Fatal(err)
+	} else if want := []byte{Replaced, Removed, Removed, Replaced, Removed}; !reflect.DeepEqual(ts, want) {
+		t.Fatalf("tombstones = %v, want %v", ts, want)
+	}
+	{
+		xids, xbs, err := vs.Vectors(roaring.BitmapOf(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(xids, []int64{10}) || !reflect.DeepEqual(xbs, [][]float32{{2, 2}}) {
+			t.Fatalf("vectors = %v, %v, want [10], [[2 2]]", xids, xbs)
+		}
+	}
+	{
+		xbs, err := vs.Get([]uint32{1, 1}, []int64{10, 12})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(xbs, [][]float32{{2, 2}, nil}) {
+			t.Fatalf("get = %v, want [[2 2] []]", xbs)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import "github.com/deepfabric/vectorsql/pkg/request"
 
 type Vector interface {
 	GetVector(map[string]*request.Part) ([]float32, error)
+	GetVectors(map[string]*request.Part) (map[string][]float32, error)
 }
 
 type vector struct {
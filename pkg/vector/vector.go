@@ -2,7 +2,9 @@ package vector
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
+	"sort"
 	"strconv"
 
 	"github.com/deepfabric/vectorsql/pkg/request"
@@ -14,6 +16,58 @@ func New(url string) *vector {
 }
 
 func (v *vector) GetVector(fs map[string]*request.Part) ([]float32, error) {
+	mp, err := v.getVectors(fs)
+	if err != nil {
+		return nil, err
+	}
+	return mean(mp), nil
+}
+
+// GetVectors returns the normalized vector of every part.
+func (v *vector) GetVectors(fs map[string]*request.Part) (map[string][]float32, error) {
+	mp, err := v.getVectors(fs)
+	if err != nil {
+		return nil, err
+	}
+	xbs := make(map[string][]float32)
+	for k, v := range mp {
+		if xb := strings2Floats(v); len(xb) > 0 {
+			xbs[k] = normalize(xb)
+		}
+	}
+	return xbs, nil
+}
+
+// Combine returns the normalized weighted sum of the vectors, the weight
+// of vector is 1 if not specified.
+func Combine(xbs map[string][]float32, ws map[string]float32) ([]float32, error) {
+	var xs []float32
+
+	ks := make([]string, 0, len(xbs))
+	for k := range xbs {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks) // the sum is stable between runs
+	for _, k := range ks {
+		xb := xbs[k]
+		w, ok := ws[k]
+		if !ok {
+			w = 1
+		}
+		if len(xs) == 0 {
+			xs = make([]float32, len(xb))
+		}
+		if len(xb) != len(xs) {
+			return nil, fmt.Errorf("dimension of vector '%s' is %v, expected %v", k, len(xb), len(xs))
+		}
+		for i, x := range xb {
+			xs[i] += w * x
+		}
+	}
+	return normalize(xs), nil
+}
+
+func (v *vector) getVectors(fs map[string]*request.Part) (map[string][]string, error) {
 	var mp map[string][]string
 	var resp fasthttp.Response
 
@@ -29,7 +83,7 @@ func (v *vector) GetVector(fs map[string]*request.Part) ([]float32, error) {
 	if err := json.Unmarshal(resp.Body(), &mp); err != nil {
 		return nil, err
 	}
-	return mean(mp), nil
+	return mp, nil
 }
 
 func mean(mp map[string][]string) []float32 {
@@ -61,6 +115,21 @@ func mean(mp map[string][]string) []float32 {
 	return xs
 }
 
+func normalize(xs []float32) []float32 {
+	var y float64
+
+	for _, x := range xs {
+		y += math.Pow(float64(x), 2)
+	}
+	if y = math.Sqrt(y); y == 0 {
+		return xs
+	}
+	for i, x := range xs {
+		xs[i] = float32(float64(x) / y)
+	}
+	return xs
+}
+
 func sum(xs, ys []float32) {
 	if len(xs) != len(ys) {
 		return
@@ -0,0 +1,31 @@
+package vector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	tests := []struct {
+		xbs  map[string][]float32
+		ws   map[string]float32
+		want []float32 // nil if the vectors cannot be combined
+	}{
+		{map[string][]float32{"a": {3, 4}}, nil, []float32{0.6, 0.8}},
+		{map[string][]float32{"a": {1, 0}, "b": {0, 1}}, map[string]float32{"b": 3}, []float32{0.31622776, 0.9486833}},
+		{map[string][]float32{"a": {1, 1}, "b": {0, 1}}, map[string]float32{"b": -1}, []float32{1, 0}},
+		{map[string][]float32{"a": {1, 0}, "b": {1, 0}}, map[string]float32{"a": 1, "b": -1}, []float32{0, 0}},
+		{map[string][]float32{"a": {1, 0}, "b": {1, 0, 0}}, nil, nil},
+	}
+	for _, test := range tests {
+		xs, err := Combine(test.xbs, test.ws)
+		switch {
+		case test.want == nil && err == nil:
+			t.Errorf("Combine(%v, %v) = %v, want error", test.xbs, test.ws, xs)
+		case test.want != nil && err != nil:
+			t.Errorf("Combine(%v, %v): %v", test.xbs, test.ws, err)
+		case test.want != nil && !reflect.DeepEqual(xs, test.want):
+			t.Errorf("Combine(%v, %v) = %v, want %v", test.xbs, test.ws, xs, test.want)
+		}
+	}
+}
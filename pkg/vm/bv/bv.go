@@ -2,60 +2,236 @@ package bv
 
 import (
 	"encoding/binary"
+	"sort"
 	"time"
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/deepfabric/beevector/pkg/sdk"
 	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/storage/vectors"
+	"github.com/deepfabric/vectorsql/pkg/vm/util/distance"
 )
 
-func New(addrs []string, log logger.Log) *bv {
-	return &bv{sdk.NewClient(addrs, sdk.WithTimeout(5*time.Minute)), log}
+func New(addrs []string, vs vectors.Vectors, log logger.Log) *bv {
+	return &bv{sdk.NewClient(addrs, sdk.WithTimeout(5*time.Minute)), log, vs, metadata.DefaultLayout}
+}
+
+// WithLayout returns a bv which maps the xids to uids by l.
+func (b *bv) WithLayout(l metadata.Layout) BV {
+	return &bv{b.cli, b.log, b.vs, l}
 }
 
 func (b *bv) Add(xbs []float32, xids []int64) error {
-	return b.cli.Add(xbs, xids)
+	if err := b.cli.Add(xbs, xids); err != nil {
+		return err
+	}
+	return b.vs.Add(xbs, b.uids(xids), xids)
 }
 
-func (b *bv) Fvectors(n int64, v []float32) (*roaring.Bitmap, []uint64, error) {
-	_, vs, err := b.cli.Search(n, v, nil, false)
+// Remove removes the vectors of xids, the vector server does not support
+// deletion, so the removed xids are dropped from the search results by tombstones.
+func (b *bv) Remove(xids []int64) error {
+	return b.vs.Remove(b.uids(xids), xids)
+}
+
+// Replace replaces all the vectors of the uids of xids with xbs, the
+// other vectors of these uids are removed. The uids are marked as
+// replaced, so that their old vectors which are not stored locally are
+// dropped from the search results too.
+func (b *bv) Replace(xbs []float32, xids []int64) error {
+	uids := b.uids(xids)
+	if err := b.vs.Replace(uids); err != nil {
+		return err
+	}
+	olds, _, err := b.vs.Vectors(roaring.BitmapOf(uids...))
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	mp, ids := genIds(vs)
-	return mp, ids, nil
+	mp := make(map[int64]struct{})
+	for _, xid := range xids {
+		mp[xid] = struct{}{}
+	}
+	var rs []int64
+	for _, xid := range olds {
+		if _, ok := mp[xid]; !ok {
+			rs = append(rs, xid)
+		}
+	}
+	if len(rs) > 0 {
+		if err := b.Remove(rs); err != nil {
+			return err
+		}
+	}
+	return b.Add(xbs, xids)
 }
 
-func (b *bv) Vectors(n int64, mp *roaring.Bitmap, v []float32) (*roaring.Bitmap, []uint64, error) {
+func (b *bv) Fvectors(n int64, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	return b.search(n, v, nil)
+}
+
+func (b *bv) Vectors(n int64, mp *roaring.Bitmap, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
 	if mp != nil {
 		data, err := mp.ToBytes()
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		buf := make([]byte, 11)
 		buf[0] = 1
 		num := binary.PutUvarint(buf[1:], uint64(len(data)))
-		_, vs, err := b.cli.Search(n, v, append(buf[:1+num], data...), false)
+		return b.search(n, v, append(buf[:1+num], data...))
+	}
+	return b.search(n, v, nil)
+}
+
+// search returns the n nearest live vectors of the vector server. The
+// removed xids are dropped from the candidates, and the replaced xids
+// farther than the searched vectors may be preceded by the vectors not
+// searched yet, so the search is repeated with the number of these
+// candidates more, until there are n live candidates or all the vectors
+// are searched.
+func (b *bv) search(n int64, v []float32, mp []byte) (*roaring.Bitmap, []uint64, []float32, error) {
+	k := n
+	for {
+		ds, vs, err := b.cli.Search(k, v, mp, false)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		xids, zs, err := b.filter(v, ds, vs)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
-		mp, ids := genIds(vs)
-		return mp, ids, nil
+		isA := int64(len(vs)) < k // all the vectors are searched
+		m := len(xids)
+		for !isA && m > 0 && zs[m-1] > ds[len(ds)-1] {
+			m--
+		}
+		if int64(m) >= n || isA {
+			if int64(len(xids)) > n {
+				xids, zs = xids[:n], zs[:n]
+			}
+			rp, ids := b.genIds(xids)
+			return rp, ids, zs, nil
+		}
+		k += k - int64(m)
 	}
-	_, vs, err := b.cli.Search(n, v, nil, false)
+}
+
+// Exact computes the distances between v and every stored vector of
+// the uids in mp, and returns the n nearest ones. The vector server is
+// searched instead if some uids have no stored vectors, such as the
+// uids inserted before the vectors are stored locally.
+func (b *bv) Exact(n int64, mp *roaring.Bitmap, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	xids, xbs, err := b.vs.Vectors(mp)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if roaring.BitmapOf(b.uids(xids)...).GetCardinality() < mp.GetCardinality() {
+		return b.Vectors(n, mp, v)
+	}
+	ds := make([]float32, len(xids))
+	for i, xb := range xbs {
+		ds[i] = distance.SquaredL2(v, xb)
+	}
+	return b.nearest(n, xids, ds)
+}
+
+// Rerank computes the exact distances between v and the stored vectors
+// of the candidates vs, and returns the n nearest ones. The candidates
+// without stored vectors keep their distances ds of the vector server.
+func (b *bv) Rerank(n int64, vs []uint64, ds []float32, v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	xids := make([]int64, len(vs))
+	for i, x := range vs {
+		xids[i] = int64(x)
+	}
+	xbs, err := b.vs.Get(b.uids(xids), xids)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	zs := make([]float32, len(xids))
+	for i, xb := range xbs {
+		if xb == nil {
+			zs[i] = ds[i]
+		} else {
+			zs[i] = distance.SquaredL2(v, xb)
+		}
+	}
+	return b.nearest(n, xids, zs)
+}
+
+// nearest returns the n nearest xids by their distances ds, the
+// distances are the same as the vector server's.
+func (b *bv) nearest(n int64, xids []int64, ds []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	sort.Sort(&byDistance{ds, xids})
+	if int64(len(xids)) > n {
+		ds, xids = ds[:n], xids[:n]
+	}
+	mp, ids := b.genIds(xids)
+	return mp, ids, ds, nil
+}
+
+// filter drops the removed and duplicate xids from the search results
+// of vector server, and recomputes the distances of the replaced xids by
+// the stored vectors.
+func (b *bv) filter(v []float32, ds []float32, xids []int64) ([]int64, []float32, error) {
+	ts, err := b.vs.Tombstones(b.uids(xids), xids)
 	if err != nil {
 		return nil, nil, err
 	}
-	mp, ids := genIds(vs)
-	return mp, ids, nil
+	var isR bool
+	var ys []int64
+	var zs []float32
+
+	mp := make(map[int64]struct{})
+	for i, xid := range xids {
+		if _, ok := mp[xid]; ok {
+			continue
+		}
+		mp[xid] = struct{}{}
+		switch ts[i] {
+		case vectors.Removed:
+		case vectors.Replaced:
+			xbs, err := b.vs.Get(b.uids([]int64{xid}), []int64{xid})
+			if err != nil {
+				return nil, nil, err
+			}
+			ys = append(ys, xid)
+			if xbs[0] == nil {
+				zs = append(zs, ds[i])
+				continue
+			}
+			isR = true
+			zs = append(zs, distance.SquaredL2(v, xbs[0]))
+		default:
+			ys = append(ys, xid)
+			zs = append(zs, ds[i])
+		}
+	}
+	if isR {
+		sort.Sort(&byDistance{zs, ys})
+	}
+	return ys, zs, nil
+}
+
+func (x *byDistance) Len() int           { return len(x.ds) }
+func (x *byDistance) Less(i, j int) bool { return x.ds[i] < x.ds[j] }
+func (x *byDistance) Swap(i, j int) {
+	x.ds[i], x.ds[j] = x.ds[j], x.ds[i]
+	x.xids[i], x.xids[j] = x.xids[j], x.xids[i]
 }
 
-func genIds(vs []int64) (*roaring.Bitmap, []uint64) {
-	xs := make([]uint32, len(vs))
+func (b *bv) genIds(vs []int64) (*roaring.Bitmap, []uint64) {
 	ys := make([]uint64, len(vs))
 	for i, v := range vs {
-		xs[i] = uint32(v >> 34)
 		ys[i] = uint64(v)
 	}
-	return roaring.BitmapOf(xs...), ys
+	return roaring.BitmapOf(b.uids(vs)...), ys
+}
+
+func (b *bv) uids(xids []int64) []uint32 {
+	uids := make([]uint32, len(xids))
+	for i, xid := range xids {
+		uids[i] = b.l.Uid(xid)
+	}
+	return uids
 }
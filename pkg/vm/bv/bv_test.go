@@ -0,0 +1,210 @@
+package bv
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/thinkkv/pkg/engine/pb"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/storage/vectors"
+	"github.com/deepfabric/vectorsql/pkg/vm/util/distance"
+)
+
+// client is a vector server in memory, like the real one, it never
+// deletes or replaces the vectors.
+type client struct {
+	xids []int64
+	xbs  [][]float32
+}
+
+func (c *client) Add(xbs []float32, xids []int64) error {
+	for i, xid := range xids {
+		c.xids = append(c.xids, xid)
+		c.xbs = append(c.xbs, xbs[i*2:(i+1)*2])
+	}
+	return nil
+}
+
+func (c *client) Search(n int64, v []float32, _ []byte, _ bool) ([]float32, []int64, error) {
+	ds := make([]float32, len(c.xids))
+	xids := make([]int64, len(c.xids))
+	for i, xb := range c.xbs {
+		ds[i] = distance.SquaredL2(v, xb)
+		xids[i] = c.xids[i]
+	}
+	sort.Stable(&byDistance{ds, xids})
+	if int64(len(xids)) > n {
+		ds, xids = ds[:n], xids[:n]
+	}
+	return ds, xids, nil
+}
+
+func (c *client) AsyncSearch(n int64, v []float32, mp []byte, cb func([]float32, []int64, error), top bool) {
+	cb(c.Search(n, v, mp, top))
+}
+
+func TestReplace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	defer db.Close()
+
+	l := metadata.Layout{UidBits: 20, PidBits: 8}
+	a, b, c, d := int64(1<<8|1), int64(1<<8|2), int64(2<<8|1), int64(2<<8|2)
+	cli := &client{}
+	// a, b, c are added before the vectors are stored locally
+	if err := cli.Add([]float32{0, 0, 1, 0, 2, 0}, []int64{a, b, c}); err != nil {
+		t.Fatal(err)
+	}
+	bv := &bv{cli, nil, vectors.New(2, db), l}
+	if err := bv.Add([]float32{5, 5}, []int64{d}); err != nil {
+		t.Fatal(err)
+	}
+	check := func(xids []uint64, ds []float32) {
+		_, ys, zs, err := bv.Fvectors(10, []float32{0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ys, xids) || !reflect.DeepEqual(zs, ds) {
+			t.Fatalf("search = %v, %v, want %v, %v", ys, zs, xids, ds)
+		}
+	}
+	check([]uint64{uint64(a), uint64(b), uint64(c), uint64(d)}, []float32{0, 1, 4, 50})
+	// the old vectors of a and b are kept by the vector server
+	if err := bv.Replace([]float32{3, 0}, []int64{a}); err != nil {
+		t.Fatal(err)
+	}
+	check([]uint64{uint64(c), uint64(a), uint64(d)}, []float32{4, 9, 50})
+	if err := bv.Remove([]int64{c}); err != nil {
+		t.Fatal(err)
+	}
+	check([]uint64{uint64(a), uint64(d)}, []float32{9, 50})
+	if err := bv.Replace([]float32{1, 1}, []int64{d}); err != nil {
+		t.Fatal(err)
+	}
+	check([]uint64{uint64(d), uint64(a)}, []float32{2, 9})
+}
+
+func TestExact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	defer db.Close()
+
+	l := metadata.Layout{UidBits: 20, PidBits: 8}
+	bv := &bv{&client{}, nil, vectors.New(2, db), l}
+	xids := []int64{1<<8 | 1, 1<<8 | 2, 2<<8 | 1, 3<<8 | 1, 4<<8 | 1}
+	if err := bv.Add([]float32{4, 0, 1, 0, 2, 0, 0, 3, 0, 0}, xids); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		n    int64
+		uids []uint32
+		xids []uint64
+		ds   []float32
+	}{
+		{10, []uint32{1, 3}, []uint64{uint64(xids[1]), uint64(xids[3]), uint64(xids[0])}, []float32{1, 9, 16}},
+		{2, []uint32{1, 2, 3}, []uint64{uint64(xids[1]), uint64(xids[2])}, []float32{1, 4}},
+		{1, []uint32{4}, []uint64{uint64(xids[4])}, []float32{0}},
+	}
+	for _, test := range tests {
+		mp, ys, zs, err := bv.Exact(test.n, roaring.BitmapOf(test.uids...), []float32{0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ys, test.xids) || !reflect.DeepEqual(zs, test.ds) {
+			t.Errorf("exact(%v, %v) = %v, %v, want %v, %v", test.n, test.uids, ys, zs, test.xids, test.ds)
+		}
+		if int(mp.GetCardinality()) > len(test.uids) {
+			t.Errorf("exact(%v, %v) = %v, want a subset", test.n, test.uids, mp.ToArray())
+		}
+	}
+}
+
+func TestRerank(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	defer db.Close()
+
+	l := metadata.Layout{UidBits: 20, PidBits: 8}
+	bv := &bv{&client{}, nil, vectors.New(2, db), l}
+	a, b, c, d := int64(1<<8|1), int64(2<<8|1), int64(3<<8|1), int64(4<<8|1)
+	if err := bv.Add([]float32{3, 0, 1, 0, 2, 0}, []int64{a, b, c}); err != nil {
+		t.Fatal(err)
+	}
+	// the approximate distances of the vector server, d is not stored
+	vs := []uint64{uint64(a), uint64(b), uint64(c), uint64(d)}
+	ds := []float32{1, 2, 3, 5}
+	_, ys, zs, err := bv.Rerank(3, vs, ds, []float32{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xs := []uint64{uint64(b), uint64(c), uint64(d)}; !reflect.DeepEqual(ys, xs) || !reflect.DeepEqual(zs, []float32{1, 4, 5}) {
+		t.Errorf("rerank = %v, %v, want %v, %v", ys, zs, xs, []float32{1, 4, 5})
+	}
+}
+
+func TestSearchAfterRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	defer db.Close()
+
+	l := metadata.Layout{UidBits: 20, PidBits: 8}
+	bv := &bv{&client{}, nil, vectors.New(2, db), l}
+	var xids []int64
+	var xbs []float32
+	for i := int64(1); i <= 6; i++ {
+		xids = append(xids, i<<8|1)
+		xbs = append(xbs, float32(i), 0)
+	}
+	if err := bv.Add(xbs, xids); err != nil {
+		t.Fatal(err)
+	}
+	if err := bv.Remove(xids[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := bv.Replace([]float32{9, 0}, xids[2:3]); err != nil {
+		t.Fatal(err)
+	}
+	// the live vectors are 4, 5, 6 and the replaced 3 of distance 81
+	tests := []struct {
+		n    int64
+		xids []uint64
+		ds   []float32
+	}{
+		{2, []uint64{uint64(xids[3]), uint64(xids[4])}, []float32{16, 25}},
+		{3, []uint64{uint64(xids[3]), uint64(xids[4]), uint64(xids[5])}, []float32{16, 25, 36}},
+		{10, []uint64{uint64(xids[3]), uint64(xids[4]), uint64(xids[5]), uint64(xids[2])}, []float32{16, 25, 36, 81}},
+	}
+	for _, test := range tests {
+		mp, ys, zs, err := bv.Fvectors(test.n, []float32{0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ys, test.xids) || !reflect.DeepEqual(zs, test.ds) {
+			t.Errorf("search(%v) = %v, %v, want %v, %v", test.n, ys, zs, test.xids, test.ds)
+		}
+		if int(mp.GetCardinality()) != len(test.xids) {
+			t.Errorf("search(%v) = %v, want the uids of %v", test.n, mp.ToArray(), test.xids)
+		}
+	}
+}
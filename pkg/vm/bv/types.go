@@ -4,15 +4,29 @@ import (
 	"github.com/RoaringBitmap/roaring"
 	"github.com/deepfabric/beevector/pkg/sdk"
 	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/storage/vectors"
 )
 
 type BV interface {
 	Add([]float32, []int64) error
-	Fvectors(int64, []float32) (*roaring.Bitmap, []uint64, error)
-	Vectors(int64, *roaring.Bitmap, []float32) (*roaring.Bitmap, []uint64, error)
+	Remove([]int64) error
+	Replace([]float32, []int64) error
+	Fvectors(int64, []float32) (*roaring.Bitmap, []uint64, []float32, error)
+	Vectors(int64, *roaring.Bitmap, []float32) (*roaring.Bitmap, []uint64, []float32, error)
+	Exact(int64, *roaring.Bitmap, []float32) (*roaring.Bitmap, []uint64, []float32, error)
+	Rerank(int64, []uint64, []float32, []float32) (*roaring.Bitmap, []uint64, []float32, error)
+	WithLayout(metadata.Layout) BV
 }
 
 type bv struct {
 	cli sdk.Client
 	log logger.Log
+	vs  vectors.Vectors
+	l   metadata.Layout // bit layout of xid
+}
+
+type byDistance struct {
+	ds   []float32
+	xids []int64
 }
@@ -14,6 +14,10 @@ func (e *UnaryExtend) IsLogical() bool {
 }
 
 func (e *UnaryExtend) IsAndOnly() bool {
+	switch e.Op {
+	case overload.IsNull, overload.IsNotNull:
+		return true
+	}
 	return !overload.IsLogical(e.Op)
 }
 
@@ -43,8 +47,22 @@ func (e *UnaryExtend) ReturnType() uint32 {
 		return types.T_int
 	case overload.Typeof:
 		return types.T_string
+	case overload.Norm:
+		return types.T_float32
+	case overload.Normalize:
+		return types.T_vector
 	case overload.UnaryMinus:
 		return e.E.ReturnType()
+	case overload.ToDate:
+		return types.T_timestamp
+	case overload.ToYear:
+		return types.T_uint16
+	case overload.ToMonth, overload.ToDayOfMonth, overload.ToDayOfWeek:
+		return types.T_uint8
+	case overload.ToHour, overload.ToMinute, overload.ToSecond:
+		return types.T_uint8
+	case overload.IsNull, overload.IsNotNull:
+		return types.T_bool
 	}
 	return 0
 }
@@ -79,8 +97,19 @@ func (e *UnaryExtend) String() string {
 		return fmt.Sprintf("length(%s)", e.E.String())
 	case overload.Typeof:
 		return fmt.Sprintf("typeof(%s)", e.E.String())
+	case overload.Norm:
+		return fmt.Sprintf("L2Norm(%s)", e.E.String())
+	case overload.Normalize:
+		return fmt.Sprintf("L2Normalize(%s)", e.E.String())
 	case overload.UnaryMinus:
 		return fmt.Sprintf("-%s", e.E.String())
+	case overload.ToDate, overload.ToYear, overload.ToMonth, overload.ToDayOfMonth,
+		overload.ToDayOfWeek, overload.ToHour, overload.ToMinute, overload.ToSecond:
+		return fmt.Sprintf("%s(%s)", overload.OpName[e.Op], e.E.String())
+	case overload.IsNull:
+		return fmt.Sprintf("%s is null", e.E.String())
+	case overload.IsNotNull:
+		return fmt.Sprintf("%s is not null", e.E.String())
 	}
 	return ""
 }
@@ -140,13 +169,25 @@ func (e *BinaryExtend) ReturnType() uint32 {
 		return types.T_bool
 	case overload.NotMatch:
 		return types.T_bool
+	case overload.L2Distance, overload.CosineDistance, overload.InnerProduct:
+		return types.T_float32
 	case overload.Concat:
 		return types.T_string
+	case overload.DateTrunc, overload.AddSeconds, overload.AddMonths:
+		return types.T_timestamp
 	}
 	return 0
 }
 
 func (e *BinaryExtend) Eval(mp map[string]value.Values) (value.Values, uint32, error) {
+	if overload.IsCompare(e.Op) { // a = null is null
+		switch {
+		case e.Right == value.ConstNull && e.Left != value.ConstNull:
+			return nullCompare(e.Left, mp)
+		case e.Left == value.ConstNull && e.Right != value.ConstNull:
+			return nullCompare(e.Right, mp)
+		}
+	}
 	l, lt, err := e.Left.Eval(mp)
 	if err != nil {
 		return nil, 0, err
@@ -196,13 +237,32 @@ func (e *BinaryExtend) String() string {
 		return fmt.Sprintf("match(%s, %s)", e.Left.String(), e.Right.String())
 	case overload.NotMatch:
 		return fmt.Sprintf("notMatch(%s, %s)", e.Left.String(), e.Right.String())
+	case overload.L2Distance:
+		return fmt.Sprintf("L2Distance(%s, %s)", e.Left.String(), e.Right.String())
+	case overload.CosineDistance:
+		return fmt.Sprintf("cosineDistance(%s, %s)", e.Left.String(), e.Right.String())
+	case overload.InnerProduct:
+		return fmt.Sprintf("dotProduct(%s, %s)", e.Left.String(), e.Right.String())
 	case overload.Concat:
 		return fmt.Sprintf("%s ++ %s", e.Left.String(), e.Right.String())
+	case overload.DateTrunc, overload.AddSeconds, overload.AddMonths:
+		return fmt.Sprintf("%s(%s, %s)", overload.OpName[e.Op], e.Left.String(), e.Right.String())
 	}
 	return ""
 }
 
+// IsLogical reports whether e is a boolean expression, if and multiIf
+// are logical if all of their values are.
 func (e *MultiExtend) IsLogical() bool {
+	switch e.Op {
+	case overload.If, overload.MultiIf:
+		for i, arg := range e.Args {
+			if (i%2 == 1 || i == len(e.Args)-1) && !arg.IsLogical() {
+				return false
+			}
+		}
+		return true
+	}
 	return overload.IsLogical(e.Op)
 }
 
@@ -232,12 +292,24 @@ func (e *MultiExtend) Attributes() []string {
 }
 
 func (e *MultiExtend) ReturnType() uint32 {
-	return 0
+	switch {
+	case overload.IsLogical(e.Op):
+		return types.T_bool
+	case e.Op == overload.Concat:
+		return types.T_string
+	}
+	for i, arg := range e.Args { // the type of the first argument which is not null
+		if typ := arg.ReturnType(); typ != types.T_null && !overload.IsCondition(e.Op, i, len(e.Args)) {
+			return typ
+		}
+	}
+	return types.T_null
 }
 
 func (e *MultiExtend) Eval(mp map[string]value.Values) (value.Values, uint32, error) {
 	var err error
 	var typ uint32
+	var typs []uint32
 	var arg value.Values
 	var args []value.Values
 
@@ -246,12 +318,31 @@ func (e *MultiExtend) Eval(mp map[string]value.Values) (value.Values, uint32, er
 			return nil, 0, err
 		}
 		args = append(args, arg)
+		typs = append(typs, typ)
 	}
-	return overload.MultiEval(e.Op, typ, args)
+	return overload.MultiEval(e.Op, typs, args)
 }
 
 func (e *MultiExtend) String() string {
-	return ""
+	var s string
+
+	switch e.Op {
+	case overload.In, overload.NotIn:
+		for i, arg := range e.Args[1:] {
+			if i > 0 {
+				s += ", "
+			}
+			s += arg.String()
+		}
+		return fmt.Sprintf("%s %s (%s)", e.Args[0], overload.OpName[e.Op], s)
+	}
+	for i, arg := range e.Args {
+		if i > 0 {
+			s += ", "
+		}
+		s += arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", overload.OpName[e.Op], s)
 }
 
 func (e *ParenExtend) IsLogical() bool {
@@ -305,6 +396,15 @@ func (a *Attribute) String() string {
 	return a.Name
 }
 
+// nullCompare returns the comparison of the rows of e with null.
+func nullCompare(e Extend, mp map[string]value.Values) (value.Values, uint32, error) {
+	vs, _, err := e.Eval(mp)
+	if err != nil {
+		return nil, 0, err
+	}
+	return overload.NullCompare(vs)
+}
+
 func returnType(x, y uint32) uint32 {
 	if x == y {
 		return x
@@ -0,0 +1,1153 @@
+package overload
+
+import (
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/dynamic"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
+	"github.com/pilosa/pilosa/roaring"
+)
+
+// concat concatenates the strings of vs, the row is null if any of
+// them is null.
+func concat(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*dynamic.Strings)
+	r := &dynamic.Strings{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]string{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*dynamic.Strings)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, s := range b.Vs {
+			r.Vs[i] += s
+		}
+	}
+	return r, nil
+}
+
+// choose returns the index of the value of row i of multiIf, the
+// arguments are pairs of condition and value followed by the else value.
+func choose(vs []value.Values, i int) int {
+	for k := 0; k+1 < len(vs); k += 2 {
+		c := vs[k].(*static.Bools)
+		if c.Vs[i] && (c.Np == nil || !c.Np.Contains(uint64(i))) {
+			return k + 1
+		}
+	}
+	return len(vs) - 1
+}
+
+// IsCondition reports whether the i-th of n arguments of op is a condition.
+func IsCondition(op int, i, n int) bool {
+	return (op == If || op == MultiIf) && i%2 == 0 && i < n-1
+}
+
+// fillNulls replaces the null arguments of op by the values whose rows
+// are all null, the values have the same type as the other arguments,
+// or are booleans if they are the conditions of if and multiIf.
+func fillNulls(op int, typs []uint32, vs []value.Values) {
+	var is []uint64
+
+	n, typ := -1, uint32(types.T_null)
+	for i, v := range vs {
+		if typs[i] == types.T_null {
+			continue
+		}
+		if n < 0 {
+			var err error
+			if n, is, _, err = nulls(v); err != nil {
+				return
+			}
+		}
+		if typ == types.T_null && !IsCondition(op, i, len(vs)) {
+			typ = typs[i]
+		}
+	}
+	if n < 0 {
+		return
+	}
+	np := roaring.NewBitmap()
+	for i := 0; i < n; i++ {
+		np.DirectAdd(uint64(i))
+	}
+	for i := range vs {
+		if typs[i] != types.T_null {
+			continue
+		}
+		t := typ
+		if IsCondition(op, i, len(vs)) {
+			t = types.T_bool
+		}
+		if v := nullValues(t, n, is, np); v != nil {
+			typs[i], vs[i] = t, v
+		}
+	}
+}
+
+// nullValues returns n null rows of type typ, or nil if typ is unsupported.
+func nullValues(typ uint32, n int, is []uint64, np *roaring.Bitmap) value.Values {
+	switch typ {
+	case types.T_int:
+		return &static.Ints{Vs: make([]int64, n), Is: is, Np: np}
+	case types.T_int8:
+		return &static.Int8s{Vs: make([]int8, n), Is: is, Np: np}
+	case types.T_int16:
+		return &static.Int16s{Vs: make([]int16, n), Is: is, Np: np}
+	case types.T_int32:
+		return &static.Int32s{Vs: make([]int32, n), Is: is, Np: np}
+	case types.T_int64:
+		return &static.Int64s{Vs: make([]int64, n), Is: is, Np: np}
+	case types.T_uint8:
+		return &static.Uint8s{Vs: make([]uint8, n), Is: is, Np: np}
+	case types.T_uint16:
+		return &static.Uint16s{Vs: make([]uint16, n), Is: is, Np: np}
+	case types.T_uint32:
+		return &static.Uint32s{Vs: make([]uint32, n), Is: is, Np: np}
+	case types.T_uint64:
+		return &static.Uint64s{Vs: make([]uint64, n), Is: is, Np: np}
+	case types.T_float:
+		return &static.Floats{Vs: make([]float64, n), Is: is, Np: np}
+	case types.T_float32:
+		return &static.Float32s{Vs: make([]float32, n), Is: is, Np: np}
+	case types.T_float64:
+		return &static.Float64s{Vs: make([]float64, n), Is: is, Np: np}
+	case types.T_bool:
+		return &static.Bools{Vs: make([]bool, n), Is: is, Np: np}
+	case types.T_timestamp:
+		return &static.Timestamps{Vs: make([]int64, n), Is: is, Np: np}
+	case types.T_string:
+		return &dynamic.Strings{Vs: make([]string, n), Is: is, Np: np}
+	}
+	return nil
+}
+
+func union(a, b *roaring.Bitmap) *roaring.Bitmap {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	return a.Union(b)
+}
+
+func isNull(np *roaring.Bitmap, i uint64) bool {
+	return np != nil && np.Contains(i)
+}
+
+// extremeInts returns the greatest or the least of the rows of vs.
+func extremeInts(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Ints)
+	r := &static.Ints{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Ints)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceInts returns the first value of the rows of vs which is not null.
+func coalesceInts(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Ints)
+	r := &static.Ints{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Ints)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfInts returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfInts(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Ints)
+	r := &static.Ints{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]int64, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Ints)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeInt8s returns the greatest or the least of the rows of vs.
+func extremeInt8s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Int8s)
+	r := &static.Int8s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int8{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Int8s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceInt8s returns the first value of the rows of vs which is not null.
+func coalesceInt8s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Int8s)
+	r := &static.Int8s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int8{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Int8s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfInt8s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfInt8s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Int8s)
+	r := &static.Int8s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]int8, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Int8s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeInt16s returns the greatest or the least of the rows of vs.
+func extremeInt16s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Int16s)
+	r := &static.Int16s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int16{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Int16s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceInt16s returns the first value of the rows of vs which is not null.
+func coalesceInt16s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Int16s)
+	r := &static.Int16s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int16{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Int16s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfInt16s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfInt16s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Int16s)
+	r := &static.Int16s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]int16, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Int16s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeInt32s returns the greatest or the least of the rows of vs.
+func extremeInt32s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Int32s)
+	r := &static.Int32s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int32{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Int32s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceInt32s returns the first value of the rows of vs which is not null.
+func coalesceInt32s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Int32s)
+	r := &static.Int32s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int32{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Int32s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfInt32s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfInt32s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Int32s)
+	r := &static.Int32s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]int32, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Int32s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeInt64s returns the greatest or the least of the rows of vs.
+func extremeInt64s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Int64s)
+	r := &static.Int64s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Int64s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceInt64s returns the first value of the rows of vs which is not null.
+func coalesceInt64s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Int64s)
+	r := &static.Int64s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Int64s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfInt64s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfInt64s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Int64s)
+	r := &static.Int64s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]int64, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Int64s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeUint8s returns the greatest or the least of the rows of vs.
+func extremeUint8s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Uint8s)
+	r := &static.Uint8s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint8{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Uint8s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceUint8s returns the first value of the rows of vs which is not null.
+func coalesceUint8s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Uint8s)
+	r := &static.Uint8s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint8{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Uint8s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfUint8s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfUint8s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Uint8s)
+	r := &static.Uint8s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]uint8, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Uint8s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeUint16s returns the greatest or the least of the rows of vs.
+func extremeUint16s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Uint16s)
+	r := &static.Uint16s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint16{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Uint16s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceUint16s returns the first value of the rows of vs which is not null.
+func coalesceUint16s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Uint16s)
+	r := &static.Uint16s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint16{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Uint16s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfUint16s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfUint16s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Uint16s)
+	r := &static.Uint16s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]uint16, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Uint16s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeUint32s returns the greatest or the least of the rows of vs.
+func extremeUint32s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Uint32s)
+	r := &static.Uint32s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint32{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Uint32s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceUint32s returns the first value of the rows of vs which is not null.
+func coalesceUint32s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Uint32s)
+	r := &static.Uint32s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint32{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Uint32s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfUint32s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfUint32s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Uint32s)
+	r := &static.Uint32s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]uint32, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Uint32s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeUint64s returns the greatest or the least of the rows of vs.
+func extremeUint64s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Uint64s)
+	r := &static.Uint64s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Uint64s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceUint64s returns the first value of the rows of vs which is not null.
+func coalesceUint64s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Uint64s)
+	r := &static.Uint64s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]uint64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Uint64s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfUint64s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfUint64s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Uint64s)
+	r := &static.Uint64s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]uint64, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Uint64s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeFloats returns the greatest or the least of the rows of vs.
+func extremeFloats(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Floats)
+	r := &static.Floats{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]float64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Floats)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceFloats returns the first value of the rows of vs which is not null.
+func coalesceFloats(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Floats)
+	r := &static.Floats{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]float64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Floats)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfFloats returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfFloats(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Floats)
+	r := &static.Floats{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]float64, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Floats)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeFloat32s returns the greatest or the least of the rows of vs.
+func extremeFloat32s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Float32s)
+	r := &static.Float32s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]float32{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Float32s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceFloat32s returns the first value of the rows of vs which is not null.
+func coalesceFloat32s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Float32s)
+	r := &static.Float32s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]float32{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Float32s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfFloat32s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfFloat32s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Float32s)
+	r := &static.Float32s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]float32, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Float32s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeFloat64s returns the greatest or the least of the rows of vs.
+func extremeFloat64s(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Float64s)
+	r := &static.Float64s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]float64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Float64s)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceFloat64s returns the first value of the rows of vs which is not null.
+func coalesceFloat64s(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Float64s)
+	r := &static.Float64s{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]float64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Float64s)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfFloat64s returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfFloat64s(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Float64s)
+	r := &static.Float64s{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]float64, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Float64s)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeTimestamps returns the greatest or the least of the rows of vs.
+func extremeTimestamps(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*static.Timestamps)
+	r := &static.Timestamps{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*static.Timestamps)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceTimestamps returns the first value of the rows of vs which is not null.
+func coalesceTimestamps(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Timestamps)
+	r := &static.Timestamps{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]int64{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Timestamps)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfTimestamps returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfTimestamps(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Timestamps)
+	r := &static.Timestamps{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]int64, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Timestamps)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// extremeStrings returns the greatest or the least of the rows of vs.
+func extremeStrings(vs []value.Values, greatest bool) (value.Values, error) {
+	a := vs[0].(*dynamic.Strings)
+	r := &dynamic.Strings{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]string{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		b := v.(*dynamic.Strings)
+		r.Np, r.Dp = union(r.Np, b.Np), union(r.Dp, b.Dp)
+		for i, x := range b.Vs {
+			if (greatest && x > r.Vs[i]) || (!greatest && x < r.Vs[i]) {
+				r.Vs[i] = x
+			}
+		}
+	}
+	return r, nil
+}
+
+// coalesceStrings returns the first value of the rows of vs which is not null.
+func coalesceStrings(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*dynamic.Strings)
+	r := &dynamic.Strings{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]string{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*dynamic.Strings)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfStrings returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfStrings(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*dynamic.Strings)
+	r := &dynamic.Strings{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]string, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*dynamic.Strings)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
+
+// coalesceBools returns the first value of the rows of vs which is not null.
+func coalesceBools(vs []value.Values) (value.Values, error) {
+	a := vs[0].(*static.Bools)
+	r := &static.Bools{
+		Is: a.Is,
+		Np: a.Np,
+		Dp: a.Dp,
+		Vs: append([]bool{}, a.Vs...),
+	}
+	for _, v := range vs[1:] {
+		if r.Np == nil || r.Np.Count() == 0 {
+			break
+		}
+		b := v.(*static.Bools)
+		np := roaring.NewBitmap()
+		for _, o := range r.Np.Slice() {
+			if isNull(b.Np, o) {
+				np.Add(o)
+			} else {
+				r.Vs[o] = b.Vs[o]
+			}
+		}
+		r.Np = np
+	}
+	return r, nil
+}
+
+// multiIfBools returns the value of the first true condition of the rows
+// of vs, or the else value.
+func multiIfBools(vs []value.Values) (value.Values, error) {
+	e := vs[len(vs)-1].(*static.Bools)
+	r := &static.Bools{
+		Is: e.Is,
+		Dp: e.Dp,
+		Vs: make([]bool, len(e.Vs)),
+	}
+	for i := range r.Vs {
+		b := vs[choose(vs, i)].(*static.Bools)
+		if r.Vs[i] = b.Vs[i]; isNull(b.Np, uint64(i)) {
+			if r.Np == nil {
+				r.Np = roaring.NewBitmap()
+			}
+			r.Np.Add(uint64(i))
+		}
+	}
+	return r, nil
+}
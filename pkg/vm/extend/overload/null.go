@@ -0,0 +1,105 @@
+package overload
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/dynamic"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
+	"github.com/pilosa/pilosa/roaring"
+)
+
+// nullTest returns whether the rows of vs are null, or whether they are
+// not null if not is set. The result itself is never null.
+func nullTest(vs value.Values, not bool) (value.Values, uint32, error) {
+	n, is, np, err := nulls(vs)
+	if err != nil {
+		return nil, 0, err
+	}
+	r := &static.Bools{
+		Is: is,
+		Vs: make([]bool, n),
+	}
+	for i := range r.Vs {
+		r.Vs[i] = not
+	}
+	if np != nil {
+		for _, o := range np.Slice() {
+			if o < uint64(n) {
+				r.Vs[o] = !not
+			}
+		}
+	}
+	return r, types.T_bool, nil
+}
+
+// NullCompare returns the comparison of the rows of vs with null, which
+// is null for every row, such as a = null.
+func NullCompare(vs value.Values) (value.Values, uint32, error) {
+	n, is, _, err := nulls(vs)
+	if err != nil {
+		return nil, 0, err
+	}
+	np := roaring.NewBitmap()
+	for i := 0; i < n; i++ {
+		np.DirectAdd(uint64(i))
+	}
+	return &static.Bools{Is: is, Np: np, Vs: make([]bool, n)}, types.T_bool, nil
+}
+
+// logicNulls returns the null rows of a three-valued and (or or, if
+// dominant is true): a row is null if either side is null, unless the
+// other side is the non-null dominant value.
+func logicNulls(a, b *static.Bools, dominant bool) *roaring.Bitmap {
+	np := union(a.Np, b.Np)
+	if np == nil {
+		return nil
+	}
+	r := roaring.NewBitmap()
+	for _, o := range np.Slice() {
+		if (!isNull(a.Np, o) && a.Vs[o] == dominant) || (!isNull(b.Np, o) && b.Vs[o] == dominant) {
+			continue
+		}
+		r.DirectAdd(o)
+	}
+	return r
+}
+
+// nulls returns the number of rows, the selected rows and the null
+// rows of vs.
+func nulls(vs value.Values) (int, []uint64, *roaring.Bitmap, error) {
+	switch a := vs.(type) {
+	case *static.Ints:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Int8s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Int16s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Int32s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Int64s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Uint8s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Uint16s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Uint32s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Uint64s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Floats:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Float32s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Float64s:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Bools:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *static.Timestamps:
+		return len(a.Vs), a.Is, a.Np, nil
+	case *dynamic.Strings:
+		return len(a.Vs), a.Is, a.Np, nil
+	}
+	return 0, nil, nil, fmt.Errorf("'%T' cannot be null", vs)
+}
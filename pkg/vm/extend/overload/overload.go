@@ -11,6 +11,7 @@ import (
 	"github.com/deepfabric/vectorsql/pkg/lru"
 	"github.com/deepfabric/vectorsql/pkg/match"
 	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/util/distance"
 	"github.com/deepfabric/vectorsql/pkg/vm/value"
 	"github.com/deepfabric/vectorsql/pkg/vm/value/dynamic"
 	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
@@ -23,12 +24,25 @@ func IsLogical(op int) bool {
 		return true
 	case Not:
 		return true
+	case IsNull, IsNotNull:
+		return true
 	case And:
 		return true
 	case Like, NotLike:
 		return true
 	case Match, NotMatch:
 		return true
+	case EQ, LT, GT, LE, GE, NE:
+		return true
+	case In, NotIn:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsCompare(op int) bool {
+	switch op {
 	case EQ, LT, GT, LE, GE, NE:
 		return true
 	default:
@@ -60,6 +74,12 @@ func OperatorType(op int) int {
 		return Unary
 	case Typeof:
 		return Unary
+	case Norm, Normalize:
+		return Unary
+	case ToDate, ToYear, ToMonth, ToDayOfMonth, ToDayOfWeek, ToHour, ToMinute, ToSecond:
+		return Unary
+	case IsNull, IsNotNull:
+		return Unary
 	case Or:
 		return Binary
 	case And:
@@ -92,13 +112,27 @@ func OperatorType(op int) int {
 		return Binary
 	case Match, NotMatch:
 		return Binary
-	case Concat:
+	case L2Distance, CosineDistance, InnerProduct:
+		return Binary
+	case DateTrunc, AddSeconds, AddMonths:
 		return Binary
+	case Concat, Coalesce, Greatest, Least, If, MultiIf:
+		return Multi
+	case In, NotIn:
+		return Multi
 	}
 	return -1
 }
 
+// UnaryEval evaluates the unary operator op, is [not] null accepts the
+// values of any type.
 func UnaryEval(op int, typ uint32, vs value.Values) (value.Values, uint32, error) {
+	switch op {
+	case IsNull:
+		return nullTest(vs, false)
+	case IsNotNull:
+		return nullTest(vs, true)
+	}
 	if os, ok := UnaryOps[op]; ok {
 		for _, o := range os {
 			if unaryCheck(op, o.Typ, typ) {
@@ -122,18 +156,36 @@ func BinaryEval(op int, ltyp, rtyp uint32, as, bs value.Values) (value.Values, u
 	return nil, 0, fmt.Errorf("%s not yet implemented for %s, %s", OpName[op], types.T(ltyp), types.T(rtyp))
 }
 
-func MultiEval(op int, typ uint32, vs []value.Values) (value.Values, uint32, error) {
+// MultiEval evaluates the multiple operator op, the null arguments are
+// taken as the null values of the type of the other arguments.
+func MultiEval(op int, typs []uint32, vs []value.Values) (value.Values, uint32, error) {
+	fillNulls(op, typs, vs)
 	if os, ok := MultiOps[op]; ok {
 		for _, o := range os {
-			if n := vs[0].Count(); n >= o.Min && (o.Max == -1 || n <= o.Max) {
-				if multiCheck(op, o.Typ, typ) {
+			if n := len(vs); n >= o.Min && (o.Max == -1 || n <= o.Max) {
+				if multiCheck(op, o.Typ, typs) {
 					rs, err := o.Fn(vs)
 					return rs, o.ReturnType, err
 				}
 			}
 		}
 	}
-	return nil, 0, fmt.Errorf("%s not yet implemented for %s", OpName[op], types.T(typ))
+	ts := make([]string, len(typs))
+	for i, typ := range typs {
+		ts[i] = types.T(typ).String()
+	}
+	return nil, 0, fmt.Errorf("%s not yet implemented for %s", OpName[op], strings.Join(ts, ", "))
+}
+
+// MultiArgs reports whether n arguments are accepted by the multiple
+// operator op.
+func MultiArgs(op int, n int) bool {
+	for _, o := range MultiOps[op] {
+		if n >= o.Min && (o.Max == -1 || n <= o.Max) {
+			return op != MultiIf || n%2 == 1
+		}
+	}
+	return false
 }
 
 func unaryCheck(op int, arg uint32, val uint32) bool {
@@ -148,8 +200,22 @@ func binaryCheck(op int, arg0, arg1 uint32, val0, val1 uint32) bool {
 	return arg0 == val0 && arg1 == val1
 }
 
-func multiCheck(op int, arg uint32, val uint32) bool {
-	return false
+// multiCheck checks the types of arguments, the conditions of if and
+// multiIf are booleans and the others are of type arg.
+func multiCheck(op int, arg uint32, vals []uint32) bool {
+	if op == MultiIf && len(vals)%2 == 0 {
+		return false
+	}
+	for i, val := range vals {
+		if IsCondition(op, i, len(vals)) {
+			if val != types.T_bool {
+				return false
+			}
+		} else if val != arg {
+			return false
+		}
+	}
+	return true
 }
 
 var (
@@ -1469,6 +1535,99 @@ var UnaryOps = map[int][]*UnaryOp{
 			},
 		},
 	},
+	Norm: {
+		&UnaryOp{
+			Typ:        types.T_vector,
+			ReturnType: types.T_float32,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return vectorUnary(vs, distance.Norm)
+			},
+		},
+	},
+	Normalize: {
+		&UnaryOp{
+			Typ:        types.T_vector,
+			ReturnType: types.T_vector,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return vectorNormalize(vs, distance.Norm)
+			},
+		},
+	},
+	ToDate: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_timestamp,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeTimestamps(vs, func(t time.Time) time.Time {
+					t, _ = Truncate(t, "day")
+					return t
+				})
+			},
+		},
+	},
+	ToYear: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint16,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint16s(vs, func(t time.Time) uint16 { return uint16(t.Year()) })
+			},
+		},
+	},
+	ToMonth: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint8,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint8s(vs, func(t time.Time) uint8 { return uint8(t.Month()) })
+			},
+		},
+	},
+	ToDayOfMonth: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint8,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint8s(vs, func(t time.Time) uint8 { return uint8(t.Day()) })
+			},
+		},
+	},
+	ToDayOfWeek: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint8,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint8s(vs, func(t time.Time) uint8 { return uint8((t.Weekday()+6)%7 + 1) })
+			},
+		},
+	},
+	ToHour: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint8,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint8s(vs, func(t time.Time) uint8 { return uint8(t.Hour()) })
+			},
+		},
+	},
+	ToMinute: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint8,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint8s(vs, func(t time.Time) uint8 { return uint8(t.Minute()) })
+			},
+		},
+	},
+	ToSecond: {
+		&UnaryOp{
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_uint8,
+			Fn: func(vs value.Values) (value.Values, error) {
+				return timeUint8s(vs, func(t time.Time) uint8 { return uint8(t.Second()) })
+			},
+		},
+	},
 }
 
 // BinOps contains the binary operations indexed by operation type.
@@ -1484,22 +1643,13 @@ var BinOps = map[int][]*BinOp{
 					Is: a.Is,
 					Vs: make([]bool, len(a.Vs)),
 				}
-				{
-					switch {
-					case a.Np == nil && b.Np != nil:
-						r.Np = b.Np
-					case a.Np != nil && b.Np == nil:
-						r.Np = a.Np
-					case a.Np != nil && b.Np != nil:
-						r.Np = a.Np.Union(b.Np)
-					}
-				}
+				r.Np = logicNulls(a, b, true)
 				{
 					switch {
 					case a.Dp == nil && b.Dp != nil:
 						r.Dp = b.Dp
 					case a.Dp != nil && b.Dp == nil:
-						r.Dp = a.Np
+						r.Dp = a.Dp
 					case a.Dp != nil && b.Dp != nil:
 						r.Dp = a.Dp.Union(b.Dp)
 					}
@@ -1528,22 +1678,13 @@ var BinOps = map[int][]*BinOp{
 					Is: a.Is,
 					Vs: make([]bool, len(a.Vs)),
 				}
-				{
-					switch {
-					case a.Np == nil && b.Np != nil:
-						r.Np = b.Np
-					case a.Np != nil && b.Np == nil:
-						r.Np = a.Np
-					case a.Np != nil && b.Np != nil:
-						r.Np = a.Np.Union(b.Np)
-					}
-				}
+				r.Np = logicNulls(a, b, false)
 				{
 					switch {
 					case a.Dp == nil && b.Dp != nil:
 						r.Dp = b.Dp
 					case a.Dp != nil && b.Dp == nil:
-						r.Dp = a.Np
+						r.Dp = a.Dp
 					case a.Dp != nil && b.Dp != nil:
 						r.Dp = a.Dp.Union(b.Dp)
 					}
@@ -26274,7 +26415,7 @@ var BinOps = map[int][]*BinOp{
 				}
 				if len(r.Is) > 0 {
 					for _, o := range r.Is {
-						rv, err := time.Parse(value.TimestampOutputFormat, a.Vs[o])
+						rv, err := time.ParseInLocation(value.TimestampOutputFormat, a.Vs[o], value.Location)
 						if err != nil {
 							return nil, err
 						}
@@ -26282,7 +26423,7 @@ var BinOps = map[int][]*BinOp{
 					}
 				} else {
 					for i, v := range a.Vs {
-						rv, err := time.Parse(value.TimestampOutputFormat, v)
+						rv, err := time.ParseInLocation(value.TimestampOutputFormat, v, value.Location)
 						if err != nil {
 							return nil, err
 						}
@@ -26618,14 +26759,14 @@ var BinOps = map[int][]*BinOp{
 				}
 				if len(r.Is) > 0 {
 					for _, o := range r.Is {
-						r.Vs[o] = time.Unix(a.Vs[o], 0).UTC().Format(value.TimestampOutputFormat)
+						r.Vs[o] = time.Unix(a.Vs[o], 0).In(value.Location).Format(value.TimestampOutputFormat)
 					}
 				} else {
 					for i, v := range a.Vs {
-						r.Vs[i] = time.Unix(v, 0).UTC().Format(value.TimestampOutputFormat)
+						r.Vs[i] = time.Unix(v, 0).In(value.Location).Format(value.TimestampOutputFormat)
 					}
 				}
-				return a, nil
+				return r, nil
 			},
 		},
 		&BinOp{
@@ -26637,50 +26778,745 @@ var BinOps = map[int][]*BinOp{
 			},
 		},
 	},
-	Concat: {
+	L2Distance: {
+		&BinOp{
+			LeftType:   types.T_vector,
+			RightType:  types.T_vector,
+			ReturnType: types.T_float32,
+			Fn: func(as, bs value.Values) (value.Values, error) {
+				return vectorBinary(as, bs, distance.L2)
+			},
+		},
+	},
+	CosineDistance: {
+		&BinOp{
+			LeftType:   types.T_vector,
+			RightType:  types.T_vector,
+			ReturnType: types.T_float32,
+			Fn: func(as, bs value.Values) (value.Values, error) {
+				return vectorBinary(as, bs, distance.Cosine)
+			},
+		},
+	},
+	InnerProduct: {
+		&BinOp{
+			LeftType:   types.T_vector,
+			RightType:  types.T_vector,
+			ReturnType: types.T_float32,
+			Fn: func(as, bs value.Values) (value.Values, error) {
+				return vectorBinary(as, bs, distance.InnerProduct)
+			},
+		},
+	},
+	DateTrunc: {
 		&BinOp{
 			LeftType:   types.T_string,
-			RightType:  types.T_string,
-			ReturnType: types.T_string,
+			RightType:  types.T_timestamp,
+			ReturnType: types.T_timestamp,
 			Fn: func(as, bs value.Values) (value.Values, error) {
-				a, b := as.(*dynamic.Strings), bs.(*dynamic.Strings)
-				r := &dynamic.Strings{
-					Is: a.Is,
-					Vs: make([]string, len(a.Vs)),
-				}
-				{
-					switch {
-					case a.Np == nil && b.Np != nil:
-						r.Np = b.Np
-					case a.Np != nil && b.Np == nil:
-						r.Np = a.Np
-					case a.Np != nil && b.Np != nil:
-						r.Np = a.Np.Union(b.Np)
-					}
-				}
-				{
-					switch {
-					case a.Dp == nil && b.Dp != nil:
-						r.Dp = b.Dp
-					case a.Dp != nil && b.Dp == nil:
-						r.Dp = a.Np
-					case a.Dp != nil && b.Dp != nil:
-						r.Dp = a.Dp.Union(b.Dp)
-					}
-				}
-				if len(r.Is) > 0 {
-					for _, o := range r.Is {
-						r.Vs[o] = a.Vs[o] + b.Vs[o]
-					}
-				} else {
-					for i, v := range a.Vs {
-						r.Vs[i] = v + b.Vs[i]
-					}
-				}
-				return r, nil
+				return dateTrunc(as, bs)
+			},
+		},
+	},
+	AddSeconds: {
+		&BinOp{
+			LeftType:   types.T_timestamp,
+			RightType:  types.T_int,
+			ReturnType: types.T_timestamp,
+			Fn: func(as, bs value.Values) (value.Values, error) {
+				return addTime(as, bs, addSeconds)
+			},
+		},
+	},
+	AddMonths: {
+		&BinOp{
+			LeftType:   types.T_timestamp,
+			RightType:  types.T_int,
+			ReturnType: types.T_timestamp,
+			Fn: func(as, bs value.Values) (value.Values, error) {
+				return addTime(as, bs, addMonths)
 			},
 		},
 	},
 }
 
-var MultiOps = map[int][]*MultiOp{}
+var MultiOps = map[int][]*MultiOp{
+	Concat: {
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_string,
+			ReturnType: types.T_string,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return concat(vs)
+			},
+		},
+	},
+	Coalesce: {
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_int,
+			ReturnType: types.T_int,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceInts(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_int8,
+			ReturnType: types.T_int8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceInt8s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_int16,
+			ReturnType: types.T_int16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceInt16s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_int32,
+			ReturnType: types.T_int32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceInt32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_int64,
+			ReturnType: types.T_int64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceInt64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_uint8,
+			ReturnType: types.T_uint8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceUint8s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_uint16,
+			ReturnType: types.T_uint16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceUint16s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_uint32,
+			ReturnType: types.T_uint32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceUint32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_uint64,
+			ReturnType: types.T_uint64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceUint64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_float,
+			ReturnType: types.T_float,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceFloats(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_float32,
+			ReturnType: types.T_float32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceFloat32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_float64,
+			ReturnType: types.T_float64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceFloat64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_timestamp,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceTimestamps(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_string,
+			ReturnType: types.T_string,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceStrings(vs)
+			},
+		},
+		&MultiOp{
+			Min:        1,
+			Max:        -1,
+			Typ:        types.T_bool,
+			ReturnType: types.T_bool,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return coalesceBools(vs)
+			},
+		},
+	},
+	Greatest: {
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int,
+			ReturnType: types.T_int,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInts(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int8,
+			ReturnType: types.T_int8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt8s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int16,
+			ReturnType: types.T_int16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt16s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int32,
+			ReturnType: types.T_int32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt32s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int64,
+			ReturnType: types.T_int64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt64s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint8,
+			ReturnType: types.T_uint8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint8s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint16,
+			ReturnType: types.T_uint16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint16s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint32,
+			ReturnType: types.T_uint32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint32s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint64,
+			ReturnType: types.T_uint64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint64s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_float,
+			ReturnType: types.T_float,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeFloats(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_float32,
+			ReturnType: types.T_float32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeFloat32s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_float64,
+			ReturnType: types.T_float64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeFloat64s(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_timestamp,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeTimestamps(vs, true)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_string,
+			ReturnType: types.T_string,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeStrings(vs, true)
+			},
+		},
+	},
+	Least: {
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int,
+			ReturnType: types.T_int,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInts(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int8,
+			ReturnType: types.T_int8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt8s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int16,
+			ReturnType: types.T_int16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt16s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int32,
+			ReturnType: types.T_int32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt32s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_int64,
+			ReturnType: types.T_int64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeInt64s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint8,
+			ReturnType: types.T_uint8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint8s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint16,
+			ReturnType: types.T_uint16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint16s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint32,
+			ReturnType: types.T_uint32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint32s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_uint64,
+			ReturnType: types.T_uint64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeUint64s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_float,
+			ReturnType: types.T_float,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeFloats(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_float32,
+			ReturnType: types.T_float32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeFloat32s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_float64,
+			ReturnType: types.T_float64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeFloat64s(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_timestamp,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeTimestamps(vs, false)
+			},
+		},
+		&MultiOp{
+			Min:        2,
+			Max:        -1,
+			Typ:        types.T_string,
+			ReturnType: types.T_string,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return extremeStrings(vs, false)
+			},
+		},
+	},
+	If: {
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_int,
+			ReturnType: types.T_int,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInts(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_int8,
+			ReturnType: types.T_int8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt8s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_int16,
+			ReturnType: types.T_int16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt16s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_int32,
+			ReturnType: types.T_int32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_int64,
+			ReturnType: types.T_int64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_uint8,
+			ReturnType: types.T_uint8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint8s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_uint16,
+			ReturnType: types.T_uint16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint16s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_uint32,
+			ReturnType: types.T_uint32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_uint64,
+			ReturnType: types.T_uint64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_float,
+			ReturnType: types.T_float,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfFloats(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_float32,
+			ReturnType: types.T_float32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfFloat32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_float64,
+			ReturnType: types.T_float64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfFloat64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_timestamp,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfTimestamps(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_string,
+			ReturnType: types.T_string,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfStrings(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        3,
+			Typ:        types.T_bool,
+			ReturnType: types.T_bool,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfBools(vs)
+			},
+		},
+	},
+	MultiIf: {
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_int,
+			ReturnType: types.T_int,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInts(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_int8,
+			ReturnType: types.T_int8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt8s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_int16,
+			ReturnType: types.T_int16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt16s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_int32,
+			ReturnType: types.T_int32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_int64,
+			ReturnType: types.T_int64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfInt64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_uint8,
+			ReturnType: types.T_uint8,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint8s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_uint16,
+			ReturnType: types.T_uint16,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint16s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_uint32,
+			ReturnType: types.T_uint32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_uint64,
+			ReturnType: types.T_uint64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfUint64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_float,
+			ReturnType: types.T_float,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfFloats(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_float32,
+			ReturnType: types.T_float32,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfFloat32s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_float64,
+			ReturnType: types.T_float64,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfFloat64s(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_timestamp,
+			ReturnType: types.T_timestamp,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfTimestamps(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_string,
+			ReturnType: types.T_string,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfStrings(vs)
+			},
+		},
+		&MultiOp{
+			Min:        3,
+			Max:        -1,
+			Typ:        types.T_bool,
+			ReturnType: types.T_bool,
+			Fn: func(vs []value.Values) (value.Values, error) {
+				return multiIfBools(vs)
+			},
+		},
+	},
+}
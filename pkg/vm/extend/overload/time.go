@@ -0,0 +1,134 @@
+package overload
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/dynamic"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
+)
+
+// timeUint8s applies fn to every time of vs.
+func timeUint8s(vs value.Values, fn func(time.Time) uint8) (value.Values, error) {
+	a := vs.(*static.Timestamps)
+	r := &static.Uint8s{
+		Np: a.Np,
+		Dp: a.Dp,
+		Is: a.Is,
+		Vs: make([]uint8, len(a.Vs)),
+	}
+	for i, v := range a.Vs {
+		r.Vs[i] = fn(unix(v))
+	}
+	return r, nil
+}
+
+// timeUint16s applies fn to every time of vs.
+func timeUint16s(vs value.Values, fn func(time.Time) uint16) (value.Values, error) {
+	a := vs.(*static.Timestamps)
+	r := &static.Uint16s{
+		Np: a.Np,
+		Dp: a.Dp,
+		Is: a.Is,
+		Vs: make([]uint16, len(a.Vs)),
+	}
+	for i, v := range a.Vs {
+		r.Vs[i] = fn(unix(v))
+	}
+	return r, nil
+}
+
+// timeTimestamps applies fn to every time of vs.
+func timeTimestamps(vs value.Values, fn func(time.Time) time.Time) (value.Values, error) {
+	a := vs.(*static.Timestamps)
+	r := &static.Timestamps{
+		Np: a.Np,
+		Dp: a.Dp,
+		Is: a.Is,
+		Vs: make([]int64, len(a.Vs)),
+	}
+	for i, v := range a.Vs {
+		r.Vs[i] = fn(unix(v)).Unix()
+	}
+	return r, nil
+}
+
+// dateTrunc truncates the times of bs to the units of as.
+func dateTrunc(as, bs value.Values) (value.Values, error) {
+	a, b := as.(*dynamic.Strings), bs.(*static.Timestamps)
+	r := &static.Timestamps{
+		Np: union(a.Np, b.Np),
+		Dp: union(a.Dp, b.Dp),
+		Is: b.Is,
+		Vs: make([]int64, len(b.Vs)),
+	}
+	for i, v := range b.Vs {
+		t, err := Truncate(unix(v), a.Vs[i])
+		if err != nil {
+			return nil, err
+		}
+		r.Vs[i] = t.Unix()
+	}
+	return r, nil
+}
+
+// addTime adds the numbers of bs to the times of as by fn.
+func addTime(as, bs value.Values, fn func(time.Time, int64) time.Time) (value.Values, error) {
+	a, b := as.(*static.Timestamps), bs.(*static.Ints)
+	r := &static.Timestamps{
+		Np: union(a.Np, b.Np),
+		Dp: union(a.Dp, b.Dp),
+		Is: a.Is,
+		Vs: make([]int64, len(a.Vs)),
+	}
+	for i, v := range a.Vs {
+		r.Vs[i] = fn(unix(v), b.Vs[i]).Unix()
+	}
+	return r, nil
+}
+
+func addSeconds(t time.Time, n int64) time.Time {
+	return t.Add(time.Duration(n) * time.Second)
+}
+
+// addMonths adds n months to t, the day is clamped to the last day of
+// the month like clickhouse, such as 2020-01-31 + 1 month is 2020-02-29.
+func addMonths(t time.Time, n int64) time.Time {
+	y, m, d := t.Date()
+	r := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := r.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return r.AddDate(0, 0, d-1)
+}
+
+// Truncate returns t rounded down to the unit in the location of t, the
+// weeks start on monday. The units are not rounded by t.Truncate, which
+// rounds the absolute time and is wrong in the zones like Asia/Kolkata.
+func Truncate(t time.Time, unit string) (time.Time, error) {
+	y, m, d := t.Date()
+	switch unit {
+	case "second":
+		return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, t.Location()), nil
+	case "minute":
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, t.Location()), nil
+	case "hour":
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location()), nil
+	case "day":
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location()), nil
+	case "week":
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location()), nil
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location()), nil
+	case "quarter":
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, t.Location()), nil
+	case "year":
+		return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location()), nil
+	}
+	return t, fmt.Errorf("unsupport unit '%s'", unit)
+}
+
+func unix(v int64) time.Time {
+	return time.Unix(v, 0).In(value.Location)
+}
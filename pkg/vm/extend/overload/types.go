@@ -23,6 +23,18 @@ const (
 	Upper
 	Length
 	Typeof
+	Norm
+	Normalize
+	ToDate
+	ToYear
+	ToMonth
+	ToDayOfMonth
+	ToDayOfWeek
+	ToHour
+	ToMinute
+	ToSecond
+	IsNull    // logical operator
+	IsNotNull // logical operator
 
 	// binary operator
 	Or  // logical operator
@@ -38,6 +50,12 @@ const (
 	NotLike
 	Match
 	NotMatch
+	L2Distance
+	CosineDistance
+	InnerProduct
+	DateTrunc  // the arguments are unit and time
+	AddSeconds // the arguments are time and number
+	AddMonths
 
 	// binary operator - comparison operator
 	EQ
@@ -49,6 +67,13 @@ const (
 
 	// multiple operator
 	Concat
+	Coalesce
+	Greatest
+	Least
+	If      // the arguments are condition, then and else
+	MultiIf // the arguments are pairs of condition and value, and else
+	In      // logical operator, the first argument is in the others
+	NotIn   // logical operator
 )
 
 var OpName = [...]string{
@@ -63,6 +88,20 @@ var OpName = [...]string{
 	Upper:      "upper",
 	Length:     "length",
 	Typeof:     "typeof",
+	Norm:       "norm",
+	Normalize:  "normalize",
+
+	ToDate:       "toDate",
+	ToYear:       "toYear",
+	ToMonth:      "toMonth",
+	ToDayOfMonth: "toDayOfMonth",
+	ToDayOfWeek:  "toDayOfWeek",
+	ToHour:       "toHour",
+	ToMinute:     "toMinute",
+	ToSecond:     "toSecond",
+
+	IsNull:    "is null",
+	IsNotNull: "is not null",
 
 	Or:       "or",
 	And:      "and",
@@ -77,6 +116,14 @@ var OpName = [...]string{
 	Match:    "match",
 	NotMatch: "not match",
 
+	L2Distance:     "l2Distance",
+	CosineDistance: "cosineDistance",
+	InnerProduct:   "innerProduct",
+
+	DateTrunc:  "dateTrunc",
+	AddSeconds: "addSeconds",
+	AddMonths:  "addMonths",
+
 	EQ: "=",
 	LT: "<",
 	GT: ">",
@@ -84,7 +131,14 @@ var OpName = [...]string{
 	GE: ">=",
 	NE: "<>",
 
-	Concat: "concat",
+	Concat:   "concat",
+	Coalesce: "coalesce",
+	Greatest: "greatest",
+	Least:    "least",
+	If:       "if",
+	MultiIf:  "multiIf",
+	In:       "in",
+	NotIn:    "not in",
 }
 
 // UnaryOp is a unary operator.
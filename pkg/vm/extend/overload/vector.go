@@ -0,0 +1,114 @@
+package overload
+
+import (
+	"fmt"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/deepfabric/vectorsql/pkg/vm/value/static"
+)
+
+// vectorUnary applies fn to every vector of vs.
+func vectorUnary(vs value.Values, fn func([]float32) float32) (value.Values, error) {
+	a := vs.(*static.Vectors)
+	n := rows(a)
+	r := &static.Float32s{
+		Np: a.Np,
+		Dp: a.Dp,
+		Is: a.Is,
+		Vs: make([]float32, n),
+	}
+	if len(r.Is) > 0 {
+		for _, o := range r.Is {
+			r.Vs[o] = fn(row(a, int(o)))
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			r.Vs[i] = fn(row(a, i))
+		}
+	}
+	return r, nil
+}
+
+// vectorBinary applies fn to every pair of vectors of as and bs.
+func vectorBinary(as, bs value.Values, fn func([]float32, []float32) float32) (value.Values, error) {
+	a, b := as.(*static.Vectors), bs.(*static.Vectors)
+	if a.Dim != b.Dim {
+		return nil, fmt.Errorf("dimension mismatch: %v, %v", a.Dim, b.Dim)
+	}
+	n := rows(a)
+	r := &static.Float32s{
+		Is: a.Is,
+		Vs: make([]float32, n),
+	}
+	{
+		switch {
+		case a.Np == nil && b.Np != nil:
+			r.Np = b.Np
+		case a.Np != nil && b.Np == nil:
+			r.Np = a.Np
+		case a.Np != nil && b.Np != nil:
+			r.Np = a.Np.Union(b.Np)
+		}
+	}
+	{
+		switch {
+		case a.Dp == nil && b.Dp != nil:
+			r.Dp = b.Dp
+		case a.Dp != nil && b.Dp == nil:
+			r.Dp = a.Dp
+		case a.Dp != nil && b.Dp != nil:
+			r.Dp = a.Dp.Union(b.Dp)
+		}
+	}
+	if len(r.Is) > 0 {
+		for _, o := range r.Is {
+			r.Vs[o] = fn(row(a, int(o)), row(b, int(o)))
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			r.Vs[i] = fn(row(a, i), row(b, i))
+		}
+	}
+	return r, nil
+}
+
+func vectorNormalize(vs value.Values, norm func([]float32) float32) (value.Values, error) {
+	a := vs.(*static.Vectors)
+	n := rows(a)
+	r := &static.Vectors{
+		Np:  a.Np,
+		Dp:  a.Dp,
+		Is:  a.Is,
+		Dim: a.Dim,
+		Vs:  make([]float32, len(a.Vs)),
+	}
+	f := func(i int) {
+		xs, ys := row(a, i), row(r, i)
+		if y := norm(xs); y != 0 {
+			for j, x := range xs {
+				ys[j] = x / y
+			}
+		}
+	}
+	if len(r.Is) > 0 {
+		for _, o := range r.Is {
+			f(int(o))
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+	}
+	return r, nil
+}
+
+func rows(a *static.Vectors) int {
+	if a.Dim == 0 {
+		return 0
+	}
+	return len(a.Vs) / a.Dim
+}
+
+func row(a *static.Vectors, i int) []float32 {
+	return a.Vs[i*a.Dim : (i+1)*a.Dim]
+}
@@ -61,13 +61,30 @@ func (n *not) negation(e extend.Extend, isParen bool) extend.Extend {
 			return v
 		}
 		return n.negationBinary(v, isParen)
+	case *extend.MultiExtend:
+		return n.negationMulti(v)
+	}
+	return e
+}
+
+func (n *not) negationMulti(e *extend.MultiExtend) extend.Extend {
+	switch e.Op {
+	case overload.In:
+		return &extend.MultiExtend{Op: overload.NotIn, Args: e.Args}
+	case overload.NotIn:
+		return &extend.MultiExtend{Op: overload.In, Args: e.Args}
 	}
 	return e
 }
 
 func (n *not) negationUnary(e *extend.UnaryExtend) extend.Extend {
-	if e.Op == overload.Not {
+	switch e.Op {
+	case overload.Not:
 		return e.E
+	case overload.IsNull:
+		return &extend.UnaryExtend{Op: overload.IsNotNull, E: e.E}
+	case overload.IsNotNull:
+		return &extend.UnaryExtend{Op: overload.IsNull, E: e.E}
 	}
 	return e
 }
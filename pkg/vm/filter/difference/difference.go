@@ -0,0 +1,30 @@
+package difference
+
+import (
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter"
+)
+
+// New returns a filter which is the uids of l but not of r.
+func New(l, r filter.Filter) *difference {
+	return &difference{l, r}
+}
+
+func (d *difference) String() string {
+	return fmt.Sprintf("(%s) AND NOT (%s)", d.l, d.r)
+}
+
+func (d *difference) Bitmap() (*roaring.Bitmap, error) {
+	mp, err := d.l.Bitmap()
+	if err != nil {
+		return nil, err
+	}
+	mq, err := d.r.Bitmap()
+	if err != nil {
+		return nil, err
+	}
+	mp.AndNot(mq)
+	return mp, nil
+}
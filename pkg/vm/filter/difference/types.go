@@ -0,0 +1,7 @@
+package difference
+
+import "github.com/deepfabric/vectorsql/pkg/vm/filter"
+
+type difference struct {
+	l, r filter.Filter
+}
@@ -4,38 +4,40 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
 	"github.com/deepfabric/vectorsql/pkg/storage"
 	"github.com/pilosa/pilosa/roaring"
 )
 
-func New(cs []*Condition, r storage.Relation) *filter {
-	return &filter{cs, r}
+// New returns the filter of conditions cs over the index of relation id.
+func New(id string, cs []*Condition, r storage.Relation) *filter {
+	return &filter{id, cs, r}
 }
 
 func (f *filter) String() string {
 	var buf bytes.Buffer
 
-	buf.WriteString(fmt.Sprintf("SELECT groupBitmapState(uid) FROM %s WHERE ", f.r))
 	for i, c := range f.cs {
 		if i > 0 {
 			buf.WriteString(" AND ")
 		}
 		switch c.Op {
-		case EQ:
-			buf.WriteString(fmt.Sprintf("%s = %s", c.Name, c.Val))
-		case NE:
-			buf.WriteString(fmt.Sprintf("%s <> %s", c.Name, c.Val))
-		case LT:
-			buf.WriteString(fmt.Sprintf("%s < %s", c.Name, c.Val))
-		case LE:
-			buf.WriteString(fmt.Sprintf("%s <= %s", c.Name, c.Val))
-		case GT:
-			buf.WriteString(fmt.Sprintf("%s > %s", c.Name, c.Val))
-		case GE:
-			buf.WriteString(fmt.Sprintf("%s >= %s", c.Name, c.Val))
+		case IN, NOTIN:
+			buf.WriteString(fmt.Sprintf("%s %s (", dialect.Ident(c.Name), opName[c.Op]))
+			for j, v := range c.Vals {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(dialect.Value(v))
+			}
+			buf.WriteString(")")
+		case ISNULL, ISNOTNULL:
+			buf.WriteString(fmt.Sprintf("%s %s", dialect.Ident(c.Name), opName[c.Op]))
+		default:
+			buf.WriteString(fmt.Sprintf("%s %s %s", dialect.Ident(c.Name), opName[c.Op], dialect.Value(c.Val)))
 		}
 	}
-	return buf.String()
+	return dialect.BitmapSelect(f.id, buf.String())
 }
 
 func (f *filter) Bitmap() (*roaring.Bitmap, error) {
@@ -103,7 +105,77 @@ func (f *filter) Bitmap() (*roaring.Bitmap, error) {
 			} else {
 				m = m.Intersect(mp)
 			}
+		case IN:
+			mp, err := f.in(c)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = mp
+			} else {
+				m = m.Intersect(mp)
+			}
+		case NOTIN:
+			mp, err := f.notIn(c)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = mp
+			} else {
+				m = m.Intersect(mp)
+			}
+		case ISNULL:
+			mp, err := f.r.IsNull(c.Name)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = mp
+			} else {
+				m = m.Intersect(mp)
+			}
+		case ISNOTNULL:
+			mp, err := f.r.IsNotNull(c.Name)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = mp
+			} else {
+				m = m.Intersect(mp)
+			}
+		}
+	}
+	return m, nil
+}
+
+// in returns the union of the bitmaps of values of c.
+func (f *filter) in(c *Condition) (*roaring.Bitmap, error) {
+	m := roaring.NewBitmap()
+	for _, v := range c.Vals {
+		mp, err := f.r.Eq(c.Name, v)
+		if err != nil {
+			return nil, err
+		}
+		m = m.Union(mp)
+	}
+	return m, nil
+}
+
+// notIn returns the intersection of the complements of values of c,
+// the null rows are excluded since not in is unknown for them.
+func (f *filter) notIn(c *Condition) (*roaring.Bitmap, error) {
+	m, err := f.r.IsNotNull(c.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range c.Vals {
+		mp, err := f.r.Ne(c.Name, v)
+		if err != nil {
+			return nil, err
 		}
+		m = m.Intersect(mp)
 	}
 	return m, nil
 }
@@ -0,0 +1,84 @@
+package ifilter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/deepfabric/thinkkv/pkg/engine/pb"
+	"github.com/deepfabric/vectorsql/pkg/lru"
+	"github.com/deepfabric/vectorsql/pkg/storage"
+	"github.com/deepfabric/vectorsql/pkg/storage/cache"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	"github.com/pilosa/pilosa/roaring"
+)
+
+func TestIn(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ifilter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := pb.New(filepath.Join(dir, "test.db"), nil, 0, false, false)
+	defer db.Close()
+	stg := storage.New(db, lru.New(10), cache.New(1<<20))
+	attrs := []metadata.Attribute{
+		{Index: true, Type: types.T_uint64, Name: "uid"},
+		{Index: true, Type: types.T_int32, Name: "level"},
+		{Index: true, Nullable: true, Type: types.T_int32, Name: "age"},
+	}
+	if err := stg.NewRelation("user_item", metadata.Metadata{Attrs: attrs}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := stg.Relation("user_item")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the age of uid 2 and 5 is null
+	if err := r.AddTuples([]interface{}{
+		[]uint64{1, 2, 3, 4, 5},
+		[]int32{1, 2, 3, 1, 2},
+		[]int32{10, 0, 30, 40, 0},
+	}, []*roaring.Bitmap{nil, nil, roaring.NewBitmap(2, 5)}); err != nil {
+		t.Fatal(err)
+	}
+	vs := func(xs ...int32) []value.Value {
+		rs := make([]value.Value, len(xs))
+		for i, x := range xs {
+			rs[i] = value.NewInt32(x)
+		}
+		return rs
+	}
+	tests := []struct {
+		c    *Condition
+		sql  string
+		want []uint64
+	}{
+		{&Condition{Op: IN, Name: "level", Vals: vs(1, 3)}, "level IN (toInt32(1), toInt32(3))", []uint64{1, 3, 4}},
+		{&Condition{Op: IN, Name: "level", Vals: vs(4)}, "level IN (toInt32(4))", []uint64{}},
+		{&Condition{Op: NOTIN, Name: "level", Vals: vs(1)}, "level NOT IN (toInt32(1))", []uint64{2, 3, 5}},
+		{&Condition{Op: NOTIN, Name: "level", Vals: vs(1, 2, 3)}, "level NOT IN (toInt32(1), toInt32(2), toInt32(3))", []uint64{}},
+		{&Condition{Op: IN, Name: "age", Vals: vs(10, 40)}, "age IN (toInt32(10), toInt32(40))", []uint64{1, 4}},
+		{&Condition{Op: IN, Name: "age", Vals: vs(0)}, "age IN (toInt32(0))", []uint64{}},
+		{&Condition{Op: NOTIN, Name: "age", Vals: vs(10)}, "age NOT IN (toInt32(10))", []uint64{3, 4}},
+		{&Condition{Op: NOTIN, Name: "age", Vals: vs(0, 30)}, "age NOT IN (toInt32(0), toInt32(30))", []uint64{1, 4}},
+		{&Condition{Op: NOTIN, Name: "age", Vals: vs(10, 30, 40)}, "age NOT IN (toInt32(10), toInt32(30), toInt32(40))", []uint64{}},
+	}
+	for _, test := range tests {
+		f := New("user_item", []*Condition{test.c}, r)
+		if s, want := f.String(), "SELECT groupBitmapState(uid) FROM user_item WHERE "+test.sql; s != want {
+			t.Errorf("String() = %s, want %s", s, want)
+		}
+		mp, err := f.Bitmap()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if xs := mp.Slice(); !reflect.DeepEqual(xs, test.want) && len(xs)+len(test.want) > 0 {
+			t.Errorf("%s = %v, want %v", test.sql, xs, test.want)
+		}
+	}
+}
@@ -13,6 +13,10 @@ const (
 	LE
 	GT
 	GE
+	IN
+	NOTIN
+	ISNULL
+	ISNOTNULL
 )
 
 type Filter interface {
@@ -21,12 +25,28 @@ type Filter interface {
 }
 
 type Condition struct {
-	Op   int // eq, ne, lt, le, gt, ge
+	Op   int // eq, ne, lt, le, gt, ge, in, not in, is null, is not null
 	Name string
 	Val  value.Value
+	Vals []value.Value // values of in and not in
+}
+
+// opName are the operators of conditions.
+var opName = [...]string{
+	EQ:        "=",
+	NE:        "<>",
+	LT:        "<",
+	LE:        "<=",
+	GT:        ">",
+	GE:        ">=",
+	IN:        "IN",
+	NOTIN:     "NOT IN",
+	ISNULL:    "IS NULL",
+	ISNOTNULL: "IS NOT NULL",
 }
 
 type filter struct {
+	id string
 	cs []*Condition
 	r  storage.Relation
 }
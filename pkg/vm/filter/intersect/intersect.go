@@ -0,0 +1,44 @@
+package intersect
+
+import (
+	"bytes"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/vectorsql/pkg/vm/filter"
+)
+
+// New returns a filter which is the intersection of fs.
+func New(fs []filter.Filter) *intersect {
+	return &intersect{fs}
+}
+
+func (r *intersect) String() string {
+	var buf bytes.Buffer
+
+	for i, f := range r.fs {
+		if i > 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString("(")
+		buf.WriteString(f.String())
+		buf.WriteString(")")
+	}
+	return buf.String()
+}
+
+func (r *intersect) Bitmap() (*roaring.Bitmap, error) {
+	var m *roaring.Bitmap
+
+	for _, f := range r.fs {
+		mp, err := f.Bitmap()
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			m = mp
+		} else {
+			m.And(mp)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,7 @@
+package intersect
+
+import "github.com/deepfabric/vectorsql/pkg/vm/filter"
+
+type intersect struct {
+	fs []filter.Filter
+}
@@ -0,0 +1,83 @@
+package op
+
+import (
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+	Roaring "github.com/pilosa/pilosa/roaring"
+)
+
+// aggregate answers the aggregations over the uids of mp by the index,
+// a row is returned for every value of the group.
+func (o *OP) aggregate(log logger.Log, mp *roaring.Bitmap) ([][]string, error) {
+	var rs [][]string
+	var fp *Roaring.Bitmap
+
+	t := time.Now()
+	if mp != nil {
+		fp = Roaring.NewBitmap()
+		itr := mp.Iterator()
+		for itr.HasNext() {
+			fp.Add(uint64(itr.Next()))
+		}
+	}
+	if len(o.A.Group) == 0 {
+		r, err := o.A.row("", fp)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	} else {
+		vs, err := o.A.R.Values(o.A.Group)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vs {
+			gp, err := o.A.R.Eq(o.A.Group, value.NewString(v))
+			if err != nil {
+				return nil, err
+			}
+			if gp != nil && fp != nil {
+				gp = gp.Intersect(fp)
+			}
+			if gp == nil || !gp.Any() {
+				continue
+			}
+			r, err := o.A.row(v, gp)
+			if err != nil {
+				return nil, err
+			}
+			rs = append(rs, r)
+		}
+	}
+	{
+		log.Debugf("aggregate process: %v\n", time.Now().Sub(t))
+	}
+	return rs, nil
+}
+
+func (a *Aggregate) row(g string, mp *Roaring.Bitmap) ([]string, error) {
+	r := make([]string, len(a.As))
+	for i, e := range a.As {
+		if e.IsG {
+			r[i] = g
+			continue
+		}
+		attr := e.Attr
+		if len(attr) == 0 { // count(*)
+			attr = "uid"
+		}
+		v, err := a.R.Aggregate(e.Op, attr, mp)
+		if err != nil {
+			return nil, err
+		}
+		if v == value.ConstNull {
+			r[i] = "NULL"
+		} else {
+			r[i] = v.String()
+		}
+	}
+	return r, nil
+}
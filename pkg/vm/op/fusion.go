@@ -0,0 +1,52 @@
+package op
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/RoaringBitmap/roaring"
+)
+
+// k of reciprocal rank fusion
+const rrfK = 60
+
+type searchFunc func([]float32) (*roaring.Bitmap, []uint64, []float32, error)
+
+// search runs fn with the query vector, or runs fn with every vector
+// of o.Vs and fuses the results by weighted reciprocal rank.
+func (o *OP) search(n int64, vec []float32, fn searchFunc) (*roaring.Bitmap, []uint64, []float32, error) {
+	if len(o.Vs) == 0 {
+		return fn(vec)
+	}
+	if o.T.IsR {
+		return nil, nil, nil, errors.New("distance range search not support with reciprocal rank fusion")
+	}
+	var xids []uint64
+
+	scores := make(map[uint64]float32)
+	for _, v := range o.Vs {
+		_, vs, _, err := fn(v.Xb)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for i, xid := range vs {
+			if _, ok := scores[xid]; !ok {
+				xids = append(xids, xid)
+			}
+			scores[xid] += v.W / float32(rrfK+i+1)
+		}
+	}
+	sort.SliceStable(xids, func(i, j int) bool { return scores[xids[i]] > scores[xids[j]] })
+	var xs []uint32
+	var ys []uint64
+	var ds []float32
+	for _, xid := range xids {
+		if int64(len(ys)) >= n || scores[xid] <= 0 {
+			break
+		}
+		xs = append(xs, o.L.Uid(int64(xid)))
+		ys = append(ys, xid)
+		ds = append(ds, -scores[xid])
+	}
+	return roaring.BitmapOf(xs...), ys, ds, nil
+}
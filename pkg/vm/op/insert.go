@@ -0,0 +1,58 @@
+package op
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/sql/client"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
+	"github.com/deepfabric/vectorsql/pkg/vm/bv"
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+	"github.com/deepfabric/vectorsql/pkg/vm/value"
+)
+
+// Tuples returns the rows in the order of attributes, the attributes
+// not given take the default values of their types, or null if they are
+// nullable.
+func (n *Insert) Tuples(log logger.Log, cfg *Config, b bv.BV, cli client.Client) ([][]string, error) {
+	rows := n.Rows
+	if n.Q != nil {
+		rs, err := n.Q.Result(log, cfg, b, cli, nil)
+		if err != nil {
+			return nil, err
+		}
+		rows = rs
+	}
+	attrs := n.R.Metadata().Attrs
+	ts := make([][]string, len(rows))
+	for i, row := range rows {
+		if len(row) != len(n.Cols) {
+			return nil, fmt.Errorf("expected %v columns, got %v", len(n.Cols), len(row))
+		}
+		t := make([]string, len(attrs))
+		for j, attr := range attrs {
+			t[j] = defaultValue(attr)
+		}
+		for j, k := range n.Cols {
+			t[k] = row[j]
+		}
+		ts[i] = t
+	}
+	return ts, nil
+}
+
+func defaultValue(attr metadata.Attribute) string {
+	if attr.Nullable {
+		return client.NullField
+	}
+	switch attr.Type {
+	case types.T_string:
+		return ""
+	case types.T_timestamp:
+		return time.Unix(0, 0).In(value.Location).Format(value.TimestampOutputFormat)
+	case types.T_vector:
+		return "[]"
+	}
+	return "0"
+}
@@ -1,57 +1,44 @@
 package op
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/deepfabric/vectorsql/pkg/logger"
 	"github.com/deepfabric/vectorsql/pkg/sql/client"
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
 	"github.com/deepfabric/vectorsql/pkg/sql/tree"
 	"github.com/deepfabric/vectorsql/pkg/vm/bv"
 )
 
-func (o *OP) Result(log logger.Log, b bv.BV, cli client.Client, vec []float32) ([][]string, error) {
-	var mp *roaring.Bitmap
-
-	if len(vec) != 512 {
-		return nil, fmt.Errorf("illegal vector '%v'", vec)
-	}
-	switch {
-	case o.Cf != nil && o.If == nil:
-		if mq, err := o.Cf.Bitmap(); err != nil {
-			return nil, err
-		} else {
-			mp = mq
-		}
-	case o.Cf == nil && o.If != nil:
-		if mq, err := o.If.Bitmap(); err != nil {
+func (o *OP) Result(log logger.Log, cfg *Config, b bv.BV, cli client.Client, vec []float32) ([][]string, error) {
+	if o.T != nil { // top, ftop, range search and the top of union
+		if err := o.checkVectors(cfg, vec); err != nil {
 			return nil, err
-		} else {
-			mp = mq
-		}
-	case o.Cf != nil && o.If != nil:
-		if mq, err := o.Cf.Bitmap(); err != nil {
-			return nil, err
-		} else {
-			mp = mq
-		}
-		if mq, err := o.If.Bitmap(); err != nil {
-			return nil, err
-		} else {
-			mp = roaring.FastAnd(mp, mq)
 		}
 	}
+	b = b.WithLayout(o.L)
+	if len(o.Us) > 0 {
+		return o.union(log, cfg, b, cli, vec)
+	}
+	mp, err := o.bitmap()
+	if err != nil {
+		return nil, err
+	}
+	if o.A != nil {
+		return o.aggregate(log, mp)
+	}
 	switch {
 	case o.T != nil && o.T.IsF:
 		t := time.Now()
-		rp, vs, err := b.Fvectors(int64(o.T.Num), vec)
+		rp, vs, ds, err := o.fvectors(cfg, b, mp, vec)
 		if err != nil {
 			return nil, err
 		}
 		{
-			log.Debugf("vector process: %v\n", time.Now().Sub(t))
+			log.Debugf("vector process(rounds = %v): %v\n", o.T.Rounds, time.Now().Sub(t))
 		}
 		if mp != nil {
 			mp.And(rp)
@@ -61,107 +48,253 @@ func (o *OP) Result(log logger.Log, b bv.BV, cli client.Client, vec []float32) (
 		is := mp.ToArray()
 		switch {
 		case len(vs) > 0 && len(is) > 0:
-			sel := o.N.Relation.(*tree.SelectClause)
-			from := sel.From
-			sel.From = nil
-			sql := fmt.Sprintf("%s FROM", o.N.String())
-			sel.From = from
-			sql += fmt.Sprintf(" (WITH %v AS xids", slice2String(vs))
-			{
-				sel.Sel = append(tree.SelectExprs{&tree.SelectExpr{
-					As: tree.Name("no"),
-					E:  &tree.Index{},
-				}}, sel.Sel...)
-				o.N.Relation = sel
-			}
-			sql += fmt.Sprintf(" %s WHERE xid IN xids AND uid IN %s ORDER BY no)", o.N, slice2String32(is))
-			return cli.Query(sql)
+			return cli.Query(o.query(vs, ds, dialect.InCandidates(is)))
 		case len(vs) == 0 && len(is) > 0:
 			return nil, nil
 		case len(vs) > 0 && len(is) == 0:
-			sel := o.N.Relation.(*tree.SelectClause)
-			from := sel.From
-			sel.From = nil
-			sql := fmt.Sprintf("%s FROM", o.N.String())
-			sel.From = from
-			sql += fmt.Sprintf(" (WITH %v AS xids", slice2String(vs))
-			{
-				sel.Sel = append(tree.SelectExprs{&tree.SelectExpr{
-					As: tree.Name("no"),
-					E:  &tree.Index{},
-				}}, sel.Sel...)
-				o.N.Relation = sel
-			}
-			sql += fmt.Sprintf(" %s WHERE xid IN xids ORDER BY no)", o.N)
-			return cli.Query(sql)
+			return cli.Query(o.query(vs, ds, dialect.InCandidates(nil)))
 		}
 		return nil, nil
 	case o.T != nil && !o.T.IsF:
-		t := time.Now()
-		_, vs, err := b.Vectors(int64(o.T.Num), mp, vec)
+		vs, ds, err := o.vectors(log, cfg, b, mp, vec)
 		if err != nil {
 			return nil, err
 		}
-		{
-			log.Debugf("vector process: %v\n", time.Now().Sub(t))
-		}
 		if len(vs) > 0 {
-			sel := o.N.Relation.(*tree.SelectClause)
-			from := sel.From
-			sel.From = nil
-			sql := fmt.Sprintf("%s FROM ", o.N.String())
-			sel.From = from
-			sql += fmt.Sprintf(" (WITH %v AS xids", slice2String(vs))
-			{
-				sel := o.N.Relation.(*tree.SelectClause)
-				sel.Sel = append(tree.SelectExprs{&tree.SelectExpr{
-					As: tree.Name("no"),
-					E:  &tree.Index{},
-				}}, sel.Sel...)
-				o.N.Relation = sel
-			}
-			sql += fmt.Sprintf(" %s WHERE xid IN xids ORDER BY no)", o.N)
-			return cli.Query(sql)
+			return cli.Query(o.query(vs, ds, dialect.InCandidates(nil)))
 		}
 		return nil, nil
 	default:
 		{
 			log.Debugf("query: '%v'\n", o.N.String())
 		}
-		if is := mp.ToArray(); len(is) > 0 {
-			return cli.Query(o.N.String() + fmt.Sprintf(" WHERE uid IN %s", slice2String32(is)))
-		} else {
-			return cli.Query(o.N.String())
+		sel := *o.N.Relation.(*tree.SelectClause)
+		sql := dialect.SelectWhere(&sel, "")
+		if mp != nil {
+			is := mp.ToArray()
+			if len(is) == 0 {
+				return nil, nil
+			}
+			sql = dialect.SelectWhere(&sel, dialect.InUids(is))
+		}
+		if o.N.Order != nil {
+			sql += " " + dialect.OrderBy(o.N.Order.(tree.OrderBy))
 		}
+		if o.N.Limit != nil {
+			sql += " " + dialect.Limit(o.N.Limit)
+		}
+		return cli.Query(sql)
 	}
 }
 
-func slice2String(is []uint64) string {
-	var buf bytes.Buffer
+// checkVectors checks the query vectors of the vector search.
+func (o *OP) checkVectors(cfg *Config, vec []float32) error {
+	if len(vec) == 0 {
+		return errors.New("vector not exist")
+	}
+	if len(vec) != cfg.Dim {
+		return fmt.Errorf("dimension of vector is %v, expected %v", len(vec), cfg.Dim)
+	}
+	for _, v := range o.Vs {
+		if len(v.Xb) != cfg.Dim {
+			return fmt.Errorf("dimension of vector is %v, expected %v", len(v.Xb), cfg.Dim)
+		}
+	}
+	return nil
+}
 
-	buf.WriteByte('[')
-	for i, v := range is {
-		if i > 0 {
-			buf.WriteString(fmt.Sprintf(", %v", v))
-		} else {
-			buf.WriteString(fmt.Sprintf("%v", v))
+// bitmap returns the uids of the filters, nil means all uids.
+func (o *OP) bitmap() (*roaring.Bitmap, error) {
+	switch {
+	case o.Cf != nil && o.If == nil:
+		return o.Cf.Bitmap()
+	case o.Cf == nil && o.If != nil:
+		return o.If.Bitmap()
+	case o.Cf != nil && o.If != nil:
+		mp, err := o.Cf.Bitmap()
+		if err != nil {
+			return nil, err
+		}
+		mq, err := o.If.Bitmap()
+		if err != nil {
+			return nil, err
 		}
+		return roaring.FastAnd(mp, mq), nil
 	}
-	buf.WriteByte(']')
-	return buf.String()
+	return nil, nil
 }
 
-func slice2String32(is []uint32) string {
-	var buf bytes.Buffer
+// vectors searches the candidates of top whose uids belong to mp.
+func (o *OP) vectors(log logger.Log, cfg *Config, b bv.BV, mp *roaring.Bitmap, vec []float32) ([]uint64, []float32, error) {
+	if mp != nil && mp.GetCardinality() <= uint64(cfg.ExactLimit) {
+		o.T.IsE = true
+	}
+	t := time.Now()
+	n := o.num(cfg)
+	_, vs, ds, err := o.search(n, vec, func(v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+		switch {
+		case o.T.IsE:
+			return b.Exact(n, mp, v)
+		case o.T.Oversample > 0:
+			_, vs, ds, err := b.Vectors(n*int64(o.T.Oversample), mp, v)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return b.Rerank(n, vs, ds, v)
+		default:
+			return b.Vectors(n, mp, v)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	{
+		log.Debugf("vector process(exact = %v): %v\n", o.T.IsE, time.Now().Sub(t))
+	}
+	if o.T.IsR {
+		_, vs, ds = o.inRange(vs, ds, o.T.Radius)
+	}
+	return vs, ds, nil
+}
+
+// fvectors searches the vectors first, and repeats the search with a
+// growing number of candidates until there are enough matches of mp
+// or the number of candidates reaches the limit.
+func (o *OP) fvectors(cfg *Config, b bv.BV, mp *roaring.Bitmap, vec []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+	n := o.num(cfg)
+	for {
+		o.T.Rounds++
+		rp, vs, ds, err := o.search(n, vec, func(v []float32) (*roaring.Bitmap, []uint64, []float32, error) {
+			return b.Fvectors(n, v)
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cnt := len(vs)
+		if o.T.IsR {
+			rp, vs, ds = o.inRange(vs, ds, o.T.Radius)
+		}
+		if mp == nil {
+			return rp, vs, ds, nil
+		}
+		xp, xs, zs := o.matches(mp, vs, ds)
+		if len(xs) >= o.T.Num || cnt < int(n) || len(vs) < cnt || n >= int64(cfg.FtopLimit) {
+			return xp, xs, zs, nil
+		}
+		if n *= 2; n > int64(cfg.FtopLimit) {
+			n = int64(cfg.FtopLimit)
+		}
+	}
+}
+
+// matches returns the first num xids of top belonging to the uids of
+// mp in the order of rank, all of them are returned if num is 0.
+func (o *OP) matches(mp *roaring.Bitmap, vs []uint64, ds []float32) (*roaring.Bitmap, []uint64, []float32) {
+	var xs []uint32
+	var ys []uint64
+	var zs []float32
+
+	for i, v := range vs {
+		if o.T.Num > 0 && len(ys) == o.T.Num {
+			break
+		}
+		if uid := o.L.Uid(int64(v)); mp.Contains(uid) {
+			xs = append(xs, uid)
+			ys = append(ys, v)
+			zs = append(zs, ds[i])
+		}
+	}
+	return roaring.BitmapOf(xs...), ys, zs
+}
+
+// num returns the number of vectors to search, a range search
+// without top is limited by the configuration, or DefaultRangeLimit
+// if the configuration does not give one.
+func (o *OP) num(cfg *Config) int64 {
+	if o.T.IsR && o.T.Num == 0 {
+		if cfg.RangeLimit <= 0 {
+			return DefaultRangeLimit
+		}
+		return int64(cfg.RangeLimit)
+	}
+	return int64(o.T.Num)
+}
+
+// inRange returns the xids whose distance is less than r.
+func (o *OP) inRange(vs []uint64, ds []float32, r float32) (*roaring.Bitmap, []uint64, []float32) {
+	var xs []uint32
+	var ys []uint64
+	var zs []float32
+
+	for i, v := range vs {
+		if ds[i] < r {
+			xs = append(xs, o.L.Uid(int64(v)))
+			ys = append(ys, v)
+			zs = append(zs, ds[i])
+		}
+	}
+	return roaring.BitmapOf(xs...), ys, zs
+}
+
+// query returns the query of the candidates which satisfy cond, the
+// rows are ordered by the rank of vector search if top has no ordering.
+func (o *OP) query(vs []uint64, ds []float32, cond string) string {
+	var sql string
 
-	buf.WriteByte('[')
-	for i, v := range is {
-		if i > 0 {
-			buf.WriteString(fmt.Sprintf(", %v", v))
+	sel := *o.N.Relation.(*tree.SelectClause)
+	if o.T.IsG { // the groups are ordered by their best candidates
+		sql = dialect.Candidates(&sel, cond, vs, o.scores(ds)) + " " + dialect.OrderByGroups(o.T.Order)
+	} else {
+		outer := sel
+		sel.Sel, outer.Sel = selectByName(sel.Sel)
+		sql = dialect.SelectFrom(&outer, dialect.Candidates(&sel, cond, vs, o.scores(ds))+" "+dialect.OrderByCandidates(o.T.Order))
+	}
+	if o.N.Limit != nil {
+		sql += " " + dialect.Limit(o.N.Limit)
+	}
+	return sql
+}
+
+// selectByName returns the select list of the subquery of candidates,
+// and the select list which selects the expressions by name out of it,
+// since the candidates are only visible in the subquery. The index of
+// candidates is selected as no by the subquery.
+func selectByName(es tree.SelectExprs) (tree.SelectExprs, tree.SelectExprs) {
+	var outer tree.SelectExprs
+
+	inner := tree.SelectExprs{&tree.SelectExpr{
+		As: tree.Name("no"),
+		E:  &tree.Index{},
+	}}
+	for i, e := range es {
+		if _, ok := e.E.(tree.ColunmNameList); ok && len(e.As) == 0 {
+			inner = append(inner, e)
+			outer = append(outer, e)
+			continue
+		}
+		as := e.As
+		if len(as) == 0 {
+			as = tree.Name(fmt.Sprintf("_%v", i))
+		}
+		inner = append(inner, &tree.SelectExpr{As: as, E: e.E})
+		outer = append(outer, &tree.SelectExpr{E: tree.ColunmNameList{{Path: as}}})
+	}
+	return inner, outer
+}
+
+// scores returns the scores of the candidates of distances ds, they are
+// only needed if similarity() is used.
+func (o *OP) scores(ds []float32) []float32 {
+	if !o.T.IsS {
+		return nil
+	}
+	ss := make([]float32, len(ds))
+	for i, d := range ds {
+		if len(o.Vs) > 0 { // negative score of reciprocal rank fusion
+			ss[i] = -d
 		} else {
-			buf.WriteString(fmt.Sprintf("%v", v))
+			ss[i] = 1 / (1 + d)
 		}
 	}
-	buf.WriteByte(']')
-	return buf.String()
+	return ss
 }
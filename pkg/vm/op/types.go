@@ -2,17 +2,82 @@ package op
 
 import (
 	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/storage"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
 	"github.com/deepfabric/vectorsql/pkg/vm/filter"
 )
 
+// DefaultRangeLimit is the maximum number of results for range search
+// without top if Config.RangeLimit is not set.
+const DefaultRangeLimit = 1000
+
+type Config struct {
+	Dim        int // dimension of the vectors of pictures
+	ExactLimit int // maximum number of candidates for exact search
+	RangeLimit int // maximum number of results for range search without top
+	FtopLimit  int // maximum number of candidates for ftop
+}
+
 type Top struct {
-	Num int
-	IsF bool
+	Num        int
+	IsF        bool
+	IsE        bool // exact search over the filtered candidates
+	IsR        bool // range search, only the vectors within radius are returned
+	Radius     float32
+	Rounds     int          // number of vector search rounds
+	Oversample int          // rerank the Num * Oversample candidates exactly if not zero
+	Order      tree.OrderBy // ordering of the candidates, similarity() is the score of vector search
+	IsS        bool         // the scores of candidates are used by similarity()
+	IsG        bool         // the candidates are grouped or aggregated
+}
+
+// Vector is a weighted query vector
+type Vector struct {
+	W  float32
+	Xb []float32
+}
+
+// Aggregation is an aggregate function answered by the index.
+type Aggregation struct {
+	IsG  bool   // value of the group
+	Op   int    // aggregate function of index
+	Attr string // attribute of aggregation
+}
+
+// Aggregate answers the aggregations by the index of R.
+type Aggregate struct {
+	R     storage.Relation
+	Group string // indexed string attribute of group by
+	As    []Aggregation
+}
+
+// Insert inserts the rows into the relation Id, the rows are either the
+// rows of values or the result of query Q, Cols are the attributes of the
+// columns of rows.
+type Insert struct {
+	Id   string
+	R    storage.Relation
+	Q    *OP
+	Cols []int
+	Rows [][]string
+}
+
+// Create creates the relation Id with metadata Md, Sql is the
+// statement creating the table of clickhouse.
+type Create struct {
+	Id  string
+	Sql string
+	Md  metadata.Metadata
 }
 
 type OP struct {
-	T  *Top
-	N  *tree.Select
-	Cf filter.Filter
-	If filter.Filter
+	L   metadata.Layout // bit layout of xid
+	T   *Top
+	N   *tree.Select
+	Vs  []Vector // query vectors fused by reciprocal rank
+	Cf  filter.Filter
+	If  filter.Filter
+	A   *Aggregate // aggregations answered by index, nil means clickhouse
+	Us  []*OP      // branches of union, the top and ordering belong to the union
+	All bool       // union all
 }
@@ -0,0 +1,147 @@
+package op
+
+import (
+	"sort"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/deepfabric/vectorsql/pkg/logger"
+	"github.com/deepfabric/vectorsql/pkg/sql/client"
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
+	"github.com/deepfabric/vectorsql/pkg/sql/tree"
+	"github.com/deepfabric/vectorsql/pkg/vm/bv"
+)
+
+// union returns the rows of the branches of o.Us. Without top the
+// branches selecting the same relation are merged by bitmap or, with
+// top the candidates of every branch are merged by score.
+func (o *OP) union(log logger.Log, cfg *Config, b bv.BV, cli client.Client, vec []float32) ([][]string, error) {
+	if o.T != nil {
+		return o.unionTop(log, cfg, b, cli, vec)
+	}
+	var rs []string
+	var us []*OP
+	var mps []*roaring.Bitmap
+
+	ms := make(map[string]int)
+	for _, u := range o.Us {
+		mp, err := u.bitmap()
+		if err != nil {
+			return nil, err
+		}
+		r := dialect.Relation(u.N.Relation)
+		if i, ok := ms[r]; ok && !o.All {
+			if mps[i] != nil {
+				if mp == nil {
+					mps[i] = nil
+				} else {
+					mps[i] = roaring.Or(mps[i], mp)
+				}
+			}
+			continue
+		}
+		ms[r] = len(rs)
+		rs = append(rs, r)
+		us = append(us, u)
+		mps = append(mps, mp)
+	}
+	var ss []string
+	for i, r := range rs {
+		switch {
+		case mps[i] == nil:
+			ss = append(ss, r)
+		case !mps[i].IsEmpty():
+			sel := *us[i].N.Relation.(*tree.SelectClause)
+			ss = append(ss, dialect.SelectWhere(&sel, dialect.InUids(mps[i].ToArray())))
+		}
+	}
+	if len(ss) == 0 {
+		return nil, nil
+	}
+	sql := dialect.Union(ss, o.All)
+	if o.N.Order != nil || o.N.Limit != nil {
+		sql = dialect.SelectFrom(&tree.SelectClause{}, sql)
+		if o.N.Order != nil {
+			sql += " " + dialect.OrderBy(o.N.Order.(tree.OrderBy))
+		}
+		if o.N.Limit != nil {
+			sql += " " + dialect.Limit(o.N.Limit)
+		}
+	}
+	{
+		log.Debugf("query: '%v'\n", sql)
+	}
+	return cli.Query(sql)
+}
+
+// unionTop searches the candidates of every branch, and keeps the
+// nearest candidates of all branches.
+func (o *OP) unionTop(log logger.Log, cfg *Config, b bv.BV, cli client.Client, vec []float32) ([][]string, error) {
+	var xids []uint64
+
+	vss := make([][]uint64, len(o.Us))
+	scores := make(map[uint64]float32)
+	for i, u := range o.Us {
+		mp, err := u.bitmap()
+		if err != nil {
+			return nil, err
+		}
+		if mp != nil && mp.IsEmpty() {
+			continue
+		}
+		t := *o.T
+		u.T, u.Vs = &t, o.Vs
+		vs, ds, err := u.vectors(log, cfg, b.WithLayout(u.L), mp, vec)
+		if err != nil {
+			return nil, err
+		}
+		vss[i] = vs
+		for j, xid := range vs {
+			if d, ok := scores[xid]; !ok {
+				xids = append(xids, xid)
+				scores[xid] = ds[j]
+			} else if ds[j] < d {
+				scores[xid] = ds[j]
+			}
+		}
+	}
+	sort.SliceStable(xids, func(i, j int) bool { return scores[xids[i]] < scores[xids[j]] })
+	if n := o.num(cfg); int64(len(xids)) > n {
+		xids = xids[:n]
+	}
+	if len(xids) == 0 {
+		return nil, nil
+	}
+	ds := make([]float32, len(xids))
+	mq := make(map[uint64]struct{})
+	for i, xid := range xids {
+		ds[i] = scores[xid]
+		mq[xid] = struct{}{}
+	}
+	var ss []string
+	for i, u := range o.Us {
+		var ys []uint64
+
+		for _, xid := range vss[i] {
+			if _, ok := mq[xid]; ok {
+				ys = append(ys, xid)
+			}
+		}
+		if len(ys) == 0 {
+			continue
+		}
+		sel := *u.N.Relation.(*tree.SelectClause)
+		sel.Sel, _ = selectByName(sel.Sel)
+		ss = append(ss, dialect.Candidates(&sel, dialect.InXids(ys), xids, o.scores(ds)))
+	}
+	// the columns of union are named by the first branch
+	sel := *o.Us[0].N.Relation.(*tree.SelectClause)
+	_, sel.Sel = selectByName(sel.Sel)
+	sql := dialect.SelectFrom(&sel, dialect.Union(ss, o.All)) + " " + dialect.OrderByCandidates(nil)
+	if o.N.Limit != nil {
+		sql += " " + dialect.Limit(o.N.Limit)
+	}
+	{
+		log.Debugf("query: '%v'\n", sql)
+	}
+	return cli.Query(sql)
+}
@@ -1,11 +1,11 @@
 package rule0
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
 	"github.com/deepfabric/vectorsql/pkg/storage"
 	"github.com/deepfabric/vectorsql/pkg/vm/context"
 	"github.com/deepfabric/vectorsql/pkg/vm/extend"
@@ -98,12 +98,46 @@ func (r *rule) disintegration(e extend.Extend, id string) (map[string]extend.Ext
 		return nil, nil, nil
 	case *extend.ParenExtend:
 		return r.disintegration(v.E, id)
+	case *extend.UnaryExtend:
+		return r.disintegrationUnary(v, id)
 	case *extend.BinaryExtend:
 		return r.disintegrationBinary(v, id)
+	case *extend.MultiExtend:
+		return r.disintegrationMulti(v, id)
 	}
 	return nil, nil, errors.New("extend must be a boolean expression")
 }
 
+func (r *rule) disintegrationUnary(e *extend.UnaryExtend, id string) (map[string]extend.Extend, map[string][]*ifilter.Condition, error) {
+	switch e.Op {
+	case overload.IsNull:
+		c, err := r.buildIsNull(e, ifilter.ISNULL, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r.genResult(e, c, id)
+	case overload.IsNotNull:
+		c, err := r.buildIsNull(e, ifilter.ISNOTNULL, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r.genResult(e, c, id)
+	}
+	return nil, nil, errors.New("extend must be a boolean expression")
+}
+
+func (r *rule) disintegrationMulti(e *extend.MultiExtend, id string) (map[string]extend.Extend, map[string][]*ifilter.Condition, error) {
+	switch e.Op {
+	case overload.In, overload.NotIn:
+		c, err := r.buildIn(e, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r.genResult(e, c, id)
+	}
+	return r.genResult(e, nil, id)
+}
+
 func (r *rule) disintegrationBinary(e *extend.BinaryExtend, id string) (map[string]extend.Extend, map[string][]*ifilter.Condition, error) {
 	switch e.Op {
 	case overload.EQ:
@@ -328,6 +362,57 @@ func (r *rule) buildGE(e *extend.BinaryExtend, id string) (*ifilter.Condition, e
 	return nil, nil
 }
 
+// buildIsNull returns the condition of is [not] null, which is served
+// by the null bitmap of attribute.
+func (r *rule) buildIsNull(e *extend.UnaryExtend, op int, id string) (*ifilter.Condition, error) {
+	lv, ok := e.E.(*extend.Attribute)
+	if !ok {
+		return nil, nil
+	}
+	typ, err := r.c.AttributeType(lv.Name, id)
+	if err != nil {
+		return nil, err
+	}
+	if !r.mq[typ] {
+		return nil, nil
+	}
+	if typ == types.T_string {
+		if ok, _ := r.c.IsIndex(lv.Name, id); !ok {
+			return nil, nil
+		}
+	}
+	return &ifilter.Condition{Op: op, Name: lv.Name}, nil
+}
+
+// buildIn returns the condition of in with the constants, the values of
+// in are searched by eq and the values of not in by ne.
+func (r *rule) buildIn(e *extend.MultiExtend, id string) (*ifilter.Condition, error) {
+	lv, ok := e.Args[0].(*extend.Attribute)
+	if !ok {
+		return nil, nil
+	}
+	typ, err := r.c.AttributeType(lv.Name, id)
+	if err != nil {
+		return nil, err
+	}
+	op, mp := ifilter.IN, r.mq
+	if e.Op == overload.NotIn {
+		op, mp = ifilter.NOTIN, r.mp
+	}
+	if !mp[typ] {
+		return nil, nil
+	}
+	vs := make([]value.Value, 0, len(e.Args)-1)
+	for _, arg := range e.Args[1:] {
+		v, ok := arg.(value.Value)
+		if !ok || !r.typeCheck(typ, v.ResolvedType(), lv.Name, id) {
+			return nil, nil
+		}
+		vs = append(vs, typeCast(typ, v))
+	}
+	return &ifilter.Condition{Op: op, Name: lv.Name, Vals: vs}, nil
+}
+
 func (r *rule) genIndexFilter(mp map[string][]*ifilter.Condition) (filter.Filter, error) {
 	fs := make([]ifilter.Filter, 0, len(mp))
 	for k, v := range mp {
@@ -335,7 +420,7 @@ func (r *rule) genIndexFilter(mp map[string][]*ifilter.Condition) (filter.Filter
 		if err != nil {
 			return nil, err
 		}
-		fs = append(fs, ifilter.New(v, r))
+		fs = append(fs, ifilter.New(k, v, r))
 	}
 	return index.New(fs), nil
 }
@@ -443,19 +528,14 @@ func (r *rule) typeCheck(x uint32, typ types.T, name, id string) bool {
 }
 
 func genQuery(mp map[string]extend.Extend) string {
-	var buf bytes.Buffer
-
 	cnt := 0
 	bs := make([]bm.Bm, 0, len(mp))
+	ds := make([]dialect.Bitmap, 0, len(mp))
 	for k, v := range mp {
-		if cnt == 0 {
-			buf.WriteString(fmt.Sprintf("WITH (SELECT groupBitmapState(uid) FROM %s where %s) AS bm%v", k, v, cnt))
-		} else {
-			buf.WriteString(fmt.Sprintf(", (SELECT groupBitmapState(uid) FROM %s where %s) AS bm%v", k, v, cnt))
-		}
-		bs = append(bs, bm.Bm{Name: fmt.Sprintf("bm%v", cnt)})
+		name := fmt.Sprintf("bm%v", cnt)
+		bs = append(bs, bm.Bm{Name: name})
+		ds = append(ds, dialect.Bitmap{Name: name, Table: k, E: v})
 		cnt++
 	}
-	buf.WriteString(fmt.Sprintf(" SELECT CAST(%s AS String) AS result", bm.Gen(bs)))
-	return buf.String()
+	return dialect.BitmapQuery(ds, bm.Gen(bs))
 }
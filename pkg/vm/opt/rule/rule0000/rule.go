@@ -1,11 +1,12 @@
 package rule0000
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 
+	"github.com/deepfabric/vectorsql/pkg/sql/dialect"
 	"github.com/deepfabric/vectorsql/pkg/storage"
+	"github.com/deepfabric/vectorsql/pkg/storage/metadata"
 	"github.com/deepfabric/vectorsql/pkg/vm/context"
 	"github.com/deepfabric/vectorsql/pkg/vm/extend"
 	"github.com/deepfabric/vectorsql/pkg/vm/extend/overload"
@@ -25,22 +26,22 @@ func (r *rule) Match(e extend.Extend) bool {
 }
 
 func (r *rule) Rewrite(e extend.Extend, id string) (filter.Filter, filter.Filter, error) {
-	bs, qs, err := r.disintegration(e, []bm.Bm{}, []string{}, id)
+	bs, qs, err := r.disintegration(e, []bm.Bm{}, []dialect.Bitmap{}, id)
 	if err != nil {
 		return nil, nil, err
 	}
 	return ck.New(r.c.Client(), genQuery(bs, qs)), nil, nil
 }
 
-func (r *rule) disintegration(e extend.Extend, bs []bm.Bm, qs []string, id string) ([]bm.Bm, []string, error) {
+func (r *rule) disintegration(e extend.Extend, bs []bm.Bm, qs []dialect.Bitmap, id string) ([]bm.Bm, []dialect.Bitmap, error) {
 	switch v := e.(type) {
 	case *value.Bool:
-		query, err := r.genResult(v, id)
+		q, err := r.genResult(v, id)
 		if err != nil {
 			return nil, nil, err
 		}
-		qs = append(qs, query)
-		bs = append(bs, bm.Bm{Name: fmt.Sprintf("bm%v", r.cnt)})
+		qs = append(qs, q)
+		bs = append(bs, bm.Bm{Name: q.Name})
 		r.cnt++
 	case *extend.ParenExtend:
 		var err error
@@ -49,6 +50,22 @@ func (r *rule) disintegration(e extend.Extend, bs []bm.Bm, qs []string, id strin
 			return nil, nil, err
 		}
 		bs = append(bs, bm.Bm{Bs: bt})
+	case *extend.UnaryExtend:
+		q, err := r.genResult(v, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		qs = append(qs, q)
+		bs = append(bs, bm.Bm{Name: q.Name})
+		r.cnt++
+	case *extend.MultiExtend:
+		q, err := r.genResult(v, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		qs = append(qs, q)
+		bs = append(bs, bm.Bm{Name: q.Name})
+		r.cnt++
 	case *extend.BinaryExtend:
 		var err error
 		if bs, qs, err = r.disintegrationBinary(v, bs, qs, id); err != nil {
@@ -58,16 +75,16 @@ func (r *rule) disintegration(e extend.Extend, bs []bm.Bm, qs []string, id strin
 	return bs, qs, nil
 }
 
-func (r *rule) disintegrationBinary(e *extend.BinaryExtend, bs []bm.Bm, qs []string, id string) ([]bm.Bm, []string, error) {
+func (r *rule) disintegrationBinary(e *extend.BinaryExtend, bs []bm.Bm, qs []dialect.Bitmap, id string) ([]bm.Bm, []dialect.Bitmap, error) {
 	switch e.Op {
 	case overload.EQ, overload.LT, overload.GT, overload.LE,
 		overload.GE, overload.NE, overload.Like, overload.NotLike:
-		query, err := r.genResult(e, id)
+		q, err := r.genResult(e, id)
 		if err != nil {
 			return nil, nil, err
 		}
-		qs = append(qs, query)
-		bs = append(bs, bm.Bm{Name: fmt.Sprintf("bm%v", r.cnt)})
+		qs = append(qs, q)
+		bs = append(bs, bm.Bm{Name: q.Name})
 		r.cnt++
 		return bs, qs, nil
 	case overload.Or:
@@ -93,18 +110,21 @@ func (r *rule) disintegrationBinary(e *extend.BinaryExtend, bs []bm.Bm, qs []str
 	return nil, nil, errors.New("extend must be a boolean expression")
 }
 
-func (r *rule) genResult(e extend.Extend, id string) (string, error) {
+// genResult returns the bitmap of e, the constant e is evaluated over
+// the item relation of id.
+func (r *rule) genResult(e extend.Extend, id string) (dialect.Bitmap, error) {
 	ts, err := r.extendBelong(e, id)
 	if err != nil {
-		return "", err
+		return dialect.Bitmap{}, err
 	}
+	name := fmt.Sprintf("bm%v", r.cnt)
 	switch len(ts) {
 	case 0:
-		return fmt.Sprintf("(SELECT groupBitmapState(uid) FROM people WHERE %s) AS bm%v", e, r.cnt), nil
+		return dialect.Bitmap{Name: name, Table: metadata.Ikey(id), E: e}, nil
 	case 1:
-		return fmt.Sprintf("(SELECT groupBitmapState(uid) FROM %s WHERE %s) AS bm%v", ts[0], e, r.cnt), nil
+		return dialect.Bitmap{Name: name, Table: ts[0], E: e}, nil
 	}
-	return "", fmt.Errorf("'%s' unsupport now", e)
+	return dialect.Bitmap{}, fmt.Errorf("'%s' unsupport now", e)
 }
 
 func (r *rule) extendBelong(e extend.Extend, id string) ([]string, error) {
@@ -128,16 +148,6 @@ func (r *rule) extendBelong(e extend.Extend, id string) ([]string, error) {
 	return nil, nil
 }
 
-func genQuery(bs []bm.Bm, qs []string) string {
-	var buf bytes.Buffer
-
-	for i, q := range qs {
-		if i == 0 {
-			buf.WriteString(fmt.Sprintf("WITH %s", q))
-		} else {
-			buf.WriteString(fmt.Sprintf(", %s", q))
-		}
-	}
-	buf.WriteString(fmt.Sprintf(" SELECT CAST(%s AS String) AS result", bm.Gen(bs)))
-	return buf.String()
+func genQuery(bs []bm.Bm, qs []dialect.Bitmap) string {
+	return dialect.BitmapQuery(qs, bm.Gen(bs))
 }
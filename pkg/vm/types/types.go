@@ -20,6 +20,7 @@ const (
 	T_int64
 	T_float32
 	T_float64
+	T_vector // float32 vector
 )
 
 type T uint32
@@ -98,6 +99,8 @@ func (t T) String() string {
 		return "FLOAT32"
 	case T_float64:
 		return "FLOAT64"
+	case T_vector:
+		return "VECTOR"
 	}
 	panic(fmt.Errorf("unexpected oid: %d", t))
 }
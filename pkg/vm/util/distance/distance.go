@@ -0,0 +1,56 @@
+package distance
+
+import "math"
+
+// L2 returns the euclidean distance between xs and ys.
+func L2(xs, ys []float32) float32 {
+	var sum float64
+
+	for i, x := range xs {
+		d := float64(x - ys[i])
+		sum += d * d
+	}
+	return float32(math.Sqrt(sum))
+}
+
+// SquaredL2 returns the squared euclidean distance between xs and ys,
+// which is the distance returned by the vector server.
+func SquaredL2(xs, ys []float32) float32 {
+	var sum float64
+
+	for i, x := range xs {
+		d := float64(x - ys[i])
+		sum += d * d
+	}
+	return float32(sum)
+}
+
+// Cosine returns the cosine distance between xs and ys.
+func Cosine(xs, ys []float32) float32 {
+	var xy, xx, yy float64
+
+	for i, x := range xs {
+		xy += float64(x) * float64(ys[i])
+		xx += float64(x) * float64(x)
+		yy += float64(ys[i]) * float64(ys[i])
+	}
+	if xx == 0 || yy == 0 {
+		return 1
+	}
+	return float32(1 - xy/math.Sqrt(xx*yy))
+}
+
+// InnerProduct returns the inner product of xs and ys.
+func InnerProduct(xs, ys []float32) float32 {
+	var sum float64
+
+	for i, x := range xs {
+		sum += float64(x) * float64(ys[i])
+	}
+	return float32(sum)
+}
+
+// Norm returns the euclidean norm of xs.
+func Norm(xs []float32) float32 {
+	return float32(math.Sqrt(float64(InnerProduct(xs, xs))))
+}
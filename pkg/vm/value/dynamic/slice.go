@@ -6,16 +6,10 @@ func (a *Strings) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Strings) Slice() ([]uint64, [][]byte) {
@@ -137,6 +137,16 @@ func (a Array) Eval(mp map[string]Values) (Values, uint32, error) {
 	return nil, types.T_array, nil
 }
 
+func (a *Vector) Eval(mp map[string]Values) (Values, uint32, error) {
+	v := []float32(*a)
+	n := length(mp)
+	vs := make([]float32, 0, n*len(v))
+	for i := 0; i < n; i++ {
+		vs = append(vs, v...)
+	}
+	return static.NewVectors(len(v), vs, nil, nil), types.T_vector, nil
+}
+
 func (a *Timestamp) Eval(mp map[string]Values) (Values, uint32, error) {
 	v := int64(*a)
 	vs := make([]int64, length(mp))
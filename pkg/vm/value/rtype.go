@@ -58,6 +58,10 @@ func (a *String) ReturnType() uint32 {
 	return types.T_string
 }
 
+func (a *Vector) ReturnType() uint32 {
+	return types.T_vector
+}
+
 func (a *Timestamp) ReturnType() uint32 {
 	return types.T_timestamp
 }
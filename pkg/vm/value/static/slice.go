@@ -11,16 +11,10 @@ func (a *Bools) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Floats) Count() int {
@@ -29,16 +23,10 @@ func (a *Floats) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Float32s) Count() int {
@@ -47,16 +35,10 @@ func (a *Float32s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Float64s) Count() int {
@@ -65,16 +47,10 @@ func (a *Float64s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Ints) Count() int {
@@ -83,16 +59,10 @@ func (a *Ints) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Int16s) Count() int {
@@ -101,16 +71,10 @@ func (a *Int16s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Int32s) Count() int {
@@ -119,16 +83,10 @@ func (a *Int32s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Int64s) Count() int {
@@ -137,16 +95,10 @@ func (a *Int64s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Int8s) Count() int {
@@ -155,16 +107,10 @@ func (a *Int8s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Timestamps) Count() int {
@@ -173,16 +119,10 @@ func (a *Timestamps) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Uint16s) Count() int {
@@ -191,16 +131,10 @@ func (a *Uint16s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Uint32s) Count() int {
@@ -209,16 +143,10 @@ func (a *Uint32s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Uint64s) Count() int {
@@ -227,16 +155,10 @@ func (a *Uint64s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Uint8s) Count() int {
@@ -245,16 +167,10 @@ func (a *Uint8s) Count() int {
 	if cnt = len(a.Is); cnt == 0 {
 		cnt = len(a.Vs)
 	}
-	switch {
-	case a.Dp == nil && a.Np == nil:
+	if a.Dp == nil { // null rows hold a slot of Vs
 		return cnt
-	case a.Dp != nil && a.Np == nil:
-		return cnt + int(a.Np.Count())
-	case a.Dp == nil && a.Np != nil:
-		return cnt - int(a.Dp.Count())
-	default: // a.Dp != nil && a.Np != nil
-		return cnt + int(a.Np.Count()-a.Dp.Count())
 	}
+	return cnt - int(a.Dp.Count())
 }
 
 func (a *Bools) Slice() ([]uint64, [][]byte) {
@@ -796,3 +712,61 @@ func (a *Uint64s) Slice() ([]uint64, [][]byte) {
 	}
 	return os, rs
 }
+
+func (a *Vectors) Count() int {
+	var cnt int
+
+	if cnt = len(a.Is); cnt == 0 {
+		cnt = a.rows()
+	}
+	switch {
+	case a.Dp == nil && a.Np == nil:
+		return cnt
+	case a.Dp != nil && a.Np == nil:
+		return cnt - int(a.Dp.Count())
+	case a.Dp == nil && a.Np != nil:
+		return cnt + int(a.Np.Count())
+	default: // a.Dp != nil && a.Np != nil
+		return cnt + int(a.Np.Count()-a.Dp.Count())
+	}
+}
+
+func (a *Vectors) Slice() ([]uint64, [][]byte) {
+	e := []byte{}
+	size := uint64(a.Dim) * 4
+	hp := *(*reflect.SliceHeader)(unsafe.Pointer(&a.Vs))
+	hp.Len *= 4
+	hp.Cap *= 4
+	data := *(*[]byte)(unsafe.Pointer(&hp))
+	if n := len(a.Is); n > 0 {
+		os := make([]uint64, 0, n)
+		rs := make([][]byte, 0, n)
+		for _, o := range a.Is {
+			if a.Dp != nil && a.Dp.Contains(o) {
+				continue
+			}
+			os = append(os, o)
+			if a.Np == nil || !a.Np.Contains(o) {
+				rs = append(rs, data[o*size:(o+1)*size])
+			} else {
+				rs = append(rs, e)
+			}
+		}
+		return os, rs
+	}
+	n := uint64(a.rows())
+	os := make([]uint64, 0, n)
+	rs := make([][]byte, 0, n)
+	for i := uint64(0); i < n; i++ {
+		if a.Dp != nil && a.Dp.Contains(i) {
+			continue
+		}
+		os = append(os, i)
+		if a.Np == nil || !a.Np.Contains(i) {
+			rs = append(rs, data[i*size:(i+1)*size])
+		} else {
+			rs = append(rs, e)
+		}
+	}
+	return os, rs
+}
@@ -155,3 +155,18 @@ func (a *Float64s) String() string {
 	}
 	return fmt.Sprintf("%v", vs)
 }
+
+func (a *Vectors) String() string {
+	var vs [][]float32
+
+	if a.Is != nil {
+		for _, o := range a.Is {
+			vs = append(vs, a.row(o))
+		}
+	} else {
+		for i, n := uint64(0), uint64(a.rows()); i < n; i++ {
+			vs = append(vs, a.row(i))
+		}
+	}
+	return fmt.Sprintf("%v", vs)
+}
@@ -99,3 +99,11 @@ type Float64s struct {
 	Np *roaring.Bitmap // null
 	Dp *roaring.Bitmap // null
 }
+
+type Vectors struct {
+	Dim int // dimension of vector
+	Vs  []float32
+	Is  []uint64
+	Np  *roaring.Bitmap // null
+	Dp  *roaring.Bitmap // null
+}
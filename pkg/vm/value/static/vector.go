@@ -0,0 +1,163 @@
+package static
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/util/encoding"
+	"github.com/pilosa/pilosa/roaring"
+)
+
+func NewVectors(dim int, vs []float32, np, dp *roaring.Bitmap) *Vectors {
+	return &Vectors{
+		Dim: dim,
+		Vs:  vs,
+		Np:  np,
+		Dp:  dp,
+	}
+}
+
+func (a *Vectors) Size() int {
+	return len(a.Vs) * 4
+}
+
+func (a *Vectors) Show() ([]byte, error) {
+	v, err := show(a.Np)
+	if err != nil {
+		return nil, err
+	}
+	v = append(v, encoding.EncodeUint32(uint32(a.Dim))...)
+	hp := *(*reflect.SliceHeader)(unsafe.Pointer(&a.Vs))
+	hp.Len *= 4
+	hp.Cap *= 4
+	return append(v, *(*[]byte)(unsafe.Pointer(&hp))...), nil
+}
+
+func (a *Vectors) Read(cnt int, data []byte) error {
+	data, np, err := read(data)
+	if err != nil {
+		return err
+	}
+	a.Np = np
+	a.Dim = int(encoding.DecodeUint32(data[:4]))
+	data = data[4:]
+	hp := *(*reflect.SliceHeader)(unsafe.Pointer(&data))
+	hp.Len = cnt * a.Dim
+	hp.Cap = cnt * a.Dim
+	a.Vs = *(*[]float32)(unsafe.Pointer(&hp))
+	return nil
+}
+
+func (a *Vectors) MarkNull(row int) error {
+	a.Np.DirectAdd(uint64(row))
+	return nil
+}
+
+func (a *Vectors) Append(v interface{}) error {
+	a.Vs = append(a.Vs, v.([]float32)...)
+	return nil
+}
+
+func (a *Vectors) Merge(np, dp *roaring.Bitmap) error {
+	a.Dp = dp
+	if a.Np == nil {
+		a.Np = np
+		return nil
+	}
+	a.Np = a.Np.Union(np)
+	return nil
+}
+
+func (a *Vectors) Update(rows []int, v interface{}) error {
+	vs := v.([]float32)
+	for _, i := range rows {
+		copy(a.Vs[i*a.Dim:(i+1)*a.Dim], vs[i*a.Dim:(i+1)*a.Dim])
+	}
+	return nil
+}
+
+func (a *Vectors) Filter(is []uint64) interface{} {
+	if len(is) == 0 {
+		return &Vectors{Dim: a.Dim}
+	}
+	return &Vectors{
+		Is:  is,
+		Dim: a.Dim,
+		Vs:  a.Vs,
+		Np:  a.Np,
+		Dp:  a.Dp,
+	}
+}
+
+func (a *Vectors) MergeFilter(v interface{}) interface{} {
+	b := v.(*Bools)
+	r := &Vectors{
+		Dim: a.Dim,
+		Vs:  a.Vs,
+	}
+	switch {
+	case a.Np != nil && b.Np == nil:
+		r.Np = a.Np
+	case a.Np == nil && b.Np != nil:
+		r.Np = b.Np
+	case a.Np != nil && b.Np != nil:
+		r.Np = a.Np.Union(b.Np)
+	}
+	switch {
+	case a.Dp != nil && b.Dp == nil:
+		r.Dp = a.Dp
+	case a.Dp == nil && b.Dp != nil:
+		r.Dp = b.Dp
+	case a.Dp != nil && b.Dp != nil:
+		r.Dp = a.Dp.Union(b.Dp)
+	}
+	switch {
+	case len(a.Is) > 0 && len(b.Is) > 0:
+		mp := make(map[uint64]struct{})
+		{
+			for _, o := range a.Is {
+				mp[o] = struct{}{}
+			}
+		}
+		r.Is = make([]uint64, 0, len(b.Is))
+		for _, o := range b.Is {
+			if _, ok := mp[o]; ok && b.Vs[o] {
+				r.Is = append(r.Is, o)
+			}
+		}
+	case len(a.Is) > 0 && len(b.Is) == 0:
+		r.Is = make([]uint64, 0, len(a.Is))
+		for _, o := range a.Is {
+			if b.Vs[o] {
+				r.Is = append(r.Is, o)
+			}
+		}
+	case len(a.Is) == 0 && len(b.Is) > 0:
+		r.Is = make([]uint64, 0, len(b.Is))
+		for _, o := range b.Is {
+			if b.Vs[o] {
+				r.Is = append(r.Is, o)
+			}
+		}
+	case len(a.Is) == 0 && len(b.Is) == 0:
+		n := a.rows()
+		r.Is = make([]uint64, 0, n)
+		for i := 0; i < n; i++ {
+			if b.Vs[i] {
+				r.Is = append(r.Is, uint64(i))
+			}
+		}
+	}
+	return r
+}
+
+func (a *Vectors) rows() int {
+	if a.Dim == 0 {
+		return 0
+	}
+	return len(a.Vs) / a.Dim
+}
+
+func (a *Vectors) row(i uint64) []float32 {
+	return a.Vs[int(i)*a.Dim : int(i+1)*a.Dim]
+}
@@ -6,13 +6,27 @@ import (
 	"github.com/deepfabric/vectorsql/pkg/vm/types"
 )
 
+// Location is the time zone of the timestamps parsed from and formatted to
+// strings, the timestamps themselves are unix time.
+var Location = time.UTC
+
+// SetLocation sets the time zone by name, such as 'Asia/Shanghai'.
+func SetLocation(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	Location = loc
+	return nil
+}
+
 func NewTimestamp(v time.Time) *Timestamp {
 	r := Timestamp(v.Unix())
 	return &r
 }
 
 func (a *Timestamp) String() string {
-	return time.Unix(int64(*a), 0).UTC().Format(TimestampOutputFormat)
+	return time.Unix(int64(*a), 0).In(Location).Format(TimestampOutputFormat)
 }
 
 func (_ *Timestamp) ResolvedType() types.T {
@@ -20,9 +34,9 @@ func (_ *Timestamp) ResolvedType() types.T {
 }
 
 // ParseTimestamp parses and returns the *Timestamp value represented by
-// the provided string in UTC, or an error if parsing is unsuccessful.
+// the provided string in Location, or an error if parsing is unsuccessful.
 func ParseTimestamp(s string) (*Timestamp, error) {
-	t, err := time.Parse(TimestampOutputFormat, s)
+	t, err := time.ParseInLocation(TimestampOutputFormat, s, Location)
 	if err != nil {
 		return nil, err
 	}
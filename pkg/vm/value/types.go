@@ -57,6 +57,7 @@ type Float64 float64
 
 type Null struct{}
 type Array []Value
+type Vector []float32
 
 type Data uint32
 type Time uint32 // 0 ~ 24 * 3600
@@ -38,6 +38,8 @@ func NewValues(v interface{}) Values {
 		return &static.Float64s{Vs: []float64{a}}
 	case string:
 		return &dynamic.Strings{Vs: []string{a}}
+	case []float32:
+		return &static.Vectors{Dim: len(a), Vs: a}
 	}
 	return nil
 }
@@ -119,6 +121,10 @@ func MustBeString(v interface{}) string {
 	return string(*(v.(*String)))
 }
 
+func MustBeVector(v interface{}) []float32 {
+	return []float32(*(v.(*Vector)))
+}
+
 func MustBeTimestamp(v interface{}) time.Time {
 	return time.Unix(int64(*(v.(*Timestamp))), 0)
 }
@@ -61,6 +61,10 @@ func (a *Float64) ToValues() Values {
 	return &static.Float64s{Vs: []float64{float64(*a)}}
 }
 
+func (a *Vector) ToValues() Values {
+	return &static.Vectors{Dim: len(*a), Vs: []float32(*a)}
+}
+
 func (a *Timestamp) ToValues() Values {
 	return &static.Timestamps{Vs: []int64{int64(*a)}}
 }
@@ -0,0 +1,83 @@
+package value
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/deepfabric/vectorsql/pkg/vm/types"
+)
+
+func NewVector(v []float32) *Vector {
+	r := Vector(v)
+	return &r
+}
+
+func (a *Vector) String() string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	for i, v := range *a {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+func (_ *Vector) ResolvedType() types.T {
+	return types.T_vector
+}
+
+// ParseVector parses and returns the *Vector value represented by the provided
+// string, such as '[0.1, 0.2]', or an error if parsing is unsuccessful.
+func ParseVector(s string) (*Vector, error) {
+	t := strings.TrimSpace(s)
+	if len(t) < 2 || t[0] != '[' || t[len(t)-1] != ']' {
+		return nil, makeParseError(s, types.T_vector, nil)
+	}
+	if t = strings.TrimSpace(t[1 : len(t)-1]); len(t) == 0 {
+		return NewVector([]float32{}), nil
+	}
+	xs := strings.Split(t, ",")
+	vs := make([]float32, len(xs))
+	for i, x := range xs {
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 32)
+		if err != nil {
+			return nil, makeParseError(s, types.T_vector, err)
+		}
+		vs[i] = float32(f)
+	}
+	return NewVector(vs), nil
+}
+
+func (a *Vector) Compare(v Value) int {
+	b, ok := v.(*Vector)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(a, v))
+	}
+	xs, ys := *a, *b
+	for i := 0; i < len(xs) && i < len(ys); i++ {
+		switch {
+		case xs[i] < ys[i]:
+			return -1
+		case xs[i] > ys[i]:
+			return 1
+		}
+	}
+	switch {
+	case len(xs) < len(ys):
+		return -1
+	case len(xs) > len(ys):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (a *Vector) Size() int            { return 1 + len(*a)*4 }
+func (_ *Vector) IsLogical() bool      { return false }
+func (_ *Vector) IsAndOnly() bool      { return true }
+func (_ *Vector) Attributes() []string { return []string{} }